@@ -0,0 +1,139 @@
+package ctable
+
+/*
+Abbreviations.
+
+Long values repeated down a column (region names, instance types, UUIDs) take up room without telling the reader much
+each time. Abbreviate() gives a column a dictionary of short forms, AbbreviateFunc() a function that works them out,
+and the column is displayed with the short forms (and sized to them):
+
+	ct.Abbreviate("Region", map[string]string{"us-east-1": "use1", "eu-west-1": "euw1"})
+	ct.AbbreviateFunc("ID", func(id string) string {
+		if len(id) > 8 {
+			return id[:8]
+		}
+		return id
+	})
+
+With AbbreviationLegend set, a legend of the short forms on show goes under the table:
+
+	use1 = us-east-1
+	euw1 = eu-west-1
+
+Abbreviations are for reading - the exports (TSV, JSON, CSV, HTML) write values in full, as does Row() etc. Masked
+columns (see mask.go) are abbreviated before they're masked, and left out of the legend.
+*/
+
+// Abbreviate displays the values of the named column found in abbreviations as their short forms, nil for none.
+func (ct *Table) Abbreviate(column string, abbreviations map[string]string) {
+
+	if abbreviations == nil {
+		ct.AbbreviateFunc(column, nil)
+		return
+	}
+
+	ct.AbbreviateFunc(column, func(value string) string {
+		if short, ok := abbreviations[value]; ok {
+			return short
+		}
+		return value
+	})
+}
+
+// AbbreviateFunc displays the values of the named column as fn returns them, nil for as they are.
+func (ct *Table) AbbreviateFunc(column string, fn func(string) string) {
+
+	c := ct.columnIndex(column)
+	if c < 0 {
+		ct.fatal("AbbreviateFunc() column " + column + " doesn't exist.")
+		return
+	}
+
+	if fn == nil {
+		delete(ct.abbreviations, c)
+	} else {
+		if ct.abbreviations == nil {
+			ct.abbreviations = map[int]func(string) string{}
+		}
+		ct.abbreviations[c] = fn
+	}
+	ct.windowLayout = nil
+}
+
+// displayed returns value (field of column c) as it's displayed - abbreviated, and masked
+func (ct *Table) displayed(c int, value string) string {
+
+	if fn := ct.abbreviations[c]; fn != nil && value != "" {
+		value = fn(value)
+	}
+
+	return ct.masked(c, value)
+}
+
+// displayedCell returns field c of display line l as it's displayed
+func (ct *Table) displayedCell(l int, c int) string {
+	return ct.displayed(c, ct.cell(l, c))
+}
+
+// displayedWidth returns the width column c is displayed at when its values are abbreviated or masked -
+// wide enough for its name and the values as displayed
+func (ct *Table) displayedWidth(c int) int {
+
+	width := 0
+	ct.scanColumn(c, func(value string) {
+		if w := textWidth(ct.displayed(c, value)); w > width {
+			width = w
+		}
+	})
+
+	col := ct.Columns[c]
+	if col.truncateAt > 0 && width > col.truncateAt {
+		width = ct.truncatedWidth(c)
+	}
+	if w := textWidth(ct.headerName(c)); w > width {
+		width = w
+	}
+
+	return width
+}
+
+// abbreviationLegend returns the legend lines for the short forms among the rows lay displays, nil if there's no legend
+func (ct *Table) abbreviationLegend(lay layout) []string {
+
+	if !ct.AbbreviationLegend || len(ct.abbreviations) == 0 {
+		return nil
+	}
+
+	var legend []string
+	seen := map[string]bool{}
+	for c := range ct.Columns {
+		fn := ct.abbreviations[c]
+		if fn == nil || ct.Columns[c].Mask != "" || lay.hidden != nil && lay.hidden[c] {
+			continue
+		}
+		for pos := 0; pos < lay.rows; pos++ {
+			first, end := ct.rowLines(lay.rowAt(pos))
+			for l := first; l < end; l++ {
+				value := ct.cell(l, c)
+				if value == "" {
+					continue
+				}
+				short := fn(value)
+				entry := short + " = " + value
+				if short != value && !seen[entry] {
+					seen[entry] = true
+					legend = append(legend, entry)
+				}
+			}
+		}
+	}
+
+	return legend
+}
+
+// printLegend prints the abbreviation legend, if there is one
+func (ct *Table) printLegend(lay layout) {
+	for _, line := range ct.abbreviationLegend(lay) {
+		ct.printLine(line)
+	}
+}
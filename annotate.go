@@ -0,0 +1,104 @@
+package ctable
+
+/*
+Row annotations.
+
+Some detail is worth having on hand without crowding the table - the full command line behind a process, the reason a
+check failed. Annotate() attaches a line of text to a row, shown indented on a line of its own under the row when the
+table is displayed with Verbose set, and not at all otherwise, so the compact view stays as it is:
+
+	ct.AddRow("web-1", "down")
+	ct.Annotate(ct.RowCount-1, "connection refused on :443 (3 attempts)")
+	ct.Verbose = *verbose
+	ct.Display(true)
+
+	Host  Status
+	===== ======
+	web-1 down
+	  connection refused on :443 (3 attempts)
+
+Annotations aren't held to the column widths (or counted in them), and a row has at most one - annotating it again
+replaces it, with "" removing it.
+*/
+
+// annotationLines stands in the layout's line order for the annotation line of row 0, annotationLines-r for row r
+const annotationLines = -3
+
+// Annotate attaches text (a single line) to row i (zero based, in the order rows were added), shown under it with Verbose set.
+func (ct *Table) Annotate(i int, text string) {
+
+	if i < 0 || i >= ct.RowCount {
+		ct.fatal("Annotate() row index is out of range.")
+		return
+	}
+
+	if text == "" {
+		delete(ct.annotations, i)
+		return
+	}
+	if ct.annotations == nil {
+		ct.annotations = map[int]string{}
+	}
+	ct.annotations[i] = text
+	ct.windowLayout = nil
+}
+
+// isAnnotationLine reports whether l (from the layout's line order) is an annotation line, and if so which row's
+func isAnnotationLine(l int) (row int, ok bool) {
+	return annotationLines - l, l <= annotationLines
+}
+
+// annotateRows adds the annotation lines to the layout, each after the last line of its row (ahead of any separator line)
+func (ct *Table) annotateRows(lay *layout) {
+
+	if !ct.Verbose || len(ct.annotations) == 0 {
+		return
+	}
+
+	firstLines := make(map[int]int, lay.rows)
+	for pos := 0; pos < lay.rows; pos++ {
+		r := lay.rowAt(pos)
+		firstLines[ct.rowStarts[r]] = r
+	}
+
+	order := make([]int, 0, lay.lines+len(ct.annotations))
+	row := -1
+	annotate := func() {
+		if _, ok := ct.annotations[row]; ok {
+			order = append(order, annotationLines-row)
+		}
+		row = -1
+	}
+	for i := 0; i < lay.lines; i++ {
+		l := lay.lineAt(i)
+		if r, ok := firstLines[l]; ok {
+			annotate()
+			row = r
+		} else if isSeparatorLine(l) {
+			annotate()
+		}
+		order = append(order, l)
+	}
+	annotate()
+
+	lay.lineOrder = order
+	lay.lines = len(order)
+}
+
+// annotationWidth returns the width of the widest annotation line lay shows
+func (ct *Table) annotationWidth(lay layout) int {
+
+	width := 0
+	for i := 0; i < lay.lines; i++ {
+		if r, ok := isAnnotationLine(lay.lineAt(i)); ok && textWidth(ct.annotationText(r)) > width {
+			width = textWidth(ct.annotationText(r))
+		}
+	}
+
+	return width
+}
+
+// annotationText returns the annotation line for row r
+func (ct *Table) annotationText(r int) string {
+	return "  " + ct.annotations[r]
+}
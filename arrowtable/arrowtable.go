@@ -0,0 +1,135 @@
+/*
+Package arrowtable hands a ctable.Table's data to analytics pipelines as an Apache Arrow record batch, column by column,
+without going through a text format.
+
+It's a separate module (with its own go.mod) so the Arrow dependency, and the many modules Arrow depends on, are only
+pulled in by programs that use it - ctable's own module doesn't require them.
+
+Example:
+
+	rec := arrowtable.ToArrow(&ct, nil)
+	defer rec.Release()
+
+Each column's Arrow type is worked out from its values, as InferTypes() does for display - int64 when every value is a
+whole number, float64 when they're numbers with fractional parts, bool for true/false (or yes/no), and string otherwise
+(dates included). Blank values and EmptyValue are nulls. Values are taken without any ANSI styling, in the table's
+sorted order (the order rows were added if it isn't sorted), with multiline values joined by newlines.
+*/
+package arrowtable
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/dcopenhaver/ctable"
+)
+
+// ToArrow returns the table's rows as an Arrow record batch with a field per column, allocated from mem
+// (nil for memory.DefaultAllocator). The caller has to Release() it.
+func ToArrow(ct *ctable.Table, mem memory.Allocator) arrow.Record {
+
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+
+	rows := make([][]string, ct.RowCount)
+	for r := range rows {
+		rows[r] = ct.OutputRow(ct.OriginalIndex(r))
+		for c := range rows[r] {
+			rows[r][c] = ansiSequence.ReplaceAllString(rows[r][c], "")
+		}
+	}
+
+	isNull := func(value string) bool {
+		return strings.TrimSpace(value) == "" || value == ct.EmptyValue
+	}
+
+	fields := make([]arrow.Field, ct.ColumnCount)
+	for c, col := range ct.Columns {
+		fields[c] = arrow.Field{Name: col.Name, Type: columnType(rows, c, isNull), Nullable: true}
+	}
+
+	b := array.NewRecordBuilder(mem, arrow.NewSchema(fields, nil))
+	defer b.Release()
+
+	for c := range fields {
+		for _, row := range rows {
+			value := row[c]
+			if isNull(value) {
+				b.Field(c).AppendNull()
+				continue
+			}
+			switch fb := b.Field(c).(type) {
+			case *array.Int64Builder:
+				n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+				fb.Append(n)
+			case *array.Float64Builder:
+				n, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				fb.Append(n)
+			case *array.BooleanBuilder:
+				fb.Append(isTrue(value))
+			case *array.StringBuilder:
+				fb.Append(value)
+			}
+		}
+	}
+
+	return b.NewRecord()
+}
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*[@-~]")
+
+// columnType returns the Arrow type for the values of column c
+func columnType(rows [][]string, c int, isNull func(string) bool) arrow.DataType {
+
+	ints, floats, bools := true, true, true
+	seen := false
+
+	for _, row := range rows {
+		value := strings.TrimSpace(row[c])
+		if isNull(row[c]) {
+			continue
+		}
+		seen = true
+
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			ints = false
+		}
+		if n, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			floats = false
+		}
+		switch strings.ToLower(value) {
+		case "true", "false", "yes", "no":
+		default:
+			bools = false
+		}
+	}
+
+	switch {
+	case !seen:
+		return arrow.BinaryTypes.String
+	case ints:
+		return arrow.PrimitiveTypes.Int64
+	case floats:
+		return arrow.PrimitiveTypes.Float64
+	case bools:
+		return arrow.FixedWidthTypes.Boolean
+	}
+
+	return arrow.BinaryTypes.String
+}
+
+// isTrue reports whether a bool column value is true
+func isTrue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package arrowtable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+
+	"github.com/dcopenhaver/ctable"
+)
+
+// testTable returns a table with a column for each Arrow type, and a blank value
+func testTable() *ctable.Table {
+
+	ct := ctable.NewTable([]ctable.Column{
+		ctable.NewColumn("Host", 0), ctable.NewColumn("Port", 0), ctable.NewColumn("Load", 0), ctable.NewColumn("Up", 0),
+	})
+	ct.AddRow("web-1", 443, "0.25", "yes")
+	ct.AddRow("db-1", 5432, "", "no")
+
+	return &ct
+}
+
+func TestToArrow(t *testing.T) {
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := ToArrow(testTable(), mem)
+	defer rec.Release()
+
+	wantTypes := []arrow.DataType{arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64, arrow.PrimitiveTypes.Float64, arrow.FixedWidthTypes.Boolean}
+	for c, want := range wantTypes {
+		if got := rec.Schema().Field(c).Type; !arrow.TypeEqual(got, want) {
+			t.Errorf("column %d: got type %s, want %s", c, got, want)
+		}
+	}
+	if rec.NumRows() != 2 {
+		t.Fatalf("got %d rows, want 2", rec.NumRows())
+	}
+
+	if got := rec.Column(0).(*array.String).Value(1); got != "db-1" {
+		t.Errorf("Host of row 2: got %q, want %q", got, "db-1")
+	}
+	if got := rec.Column(1).(*array.Int64).Value(0); got != 443 {
+		t.Errorf("Port of row 1: got %d, want 443", got)
+	}
+	if load := rec.Column(2).(*array.Float64); load.Value(0) != 0.25 || !load.IsNull(1) {
+		t.Errorf("Load: got %v, want [0.25 null]", load)
+	}
+	if up := rec.Column(3).(*array.Boolean); !up.Value(0) || up.Value(1) {
+		t.Errorf("Up: got %v, want [true false]", up)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, testTable()); err != nil {
+		t.Fatal(err)
+	}
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rdr.Close()
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbl, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tbl.Release()
+
+	if tbl.NumRows() != 2 || tbl.NumCols() != 4 {
+		t.Fatalf("got %d rows of %d columns, want 2 of 4", tbl.NumRows(), tbl.NumCols())
+	}
+	if got := tbl.Column(1).Data().Chunk(0).(*array.Int64).Value(1); got != 5432 {
+		t.Errorf("Port of row 2: got %d, want 5432", got)
+	}
+}
@@ -0,0 +1,41 @@
+package arrowtable
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+
+	"github.com/dcopenhaver/ctable"
+)
+
+/*
+Parquet files.
+
+WriteParquet() persists a table's data as a (Snappy compressed) Parquet file for later analysis, with the same column
+types as ToArrow() - it's the record batch from ToArrow() written out.
+
+	f, _ := os.Create("results.parquet")
+	err := arrowtable.WriteParquet(f, &ct)
+*/
+
+// WriteParquet writes the table's rows to w as a Parquet file.
+func WriteParquet(w io.Writer, ct *ctable.Table) error {
+
+	rec := ToArrow(ct, nil)
+	defer rec.Release()
+
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	fw, err := pqarrow.NewFileWriter(rec.Schema(), w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+
+	if err := fw.Write(rec); err != nil {
+		fw.Close()
+		return err
+	}
+
+	return fw.Close()
+}
@@ -0,0 +1,118 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Rendering in pieces.
+
+Display() prints the whole table at once. For output that comes in bit by bit - results arriving while other output
+goes on around them - RenderHeader(), RenderRows(), and RenderEnd() return the table a piece at a time instead, all
+laid out alike so the pieces line up wherever they end up:
+
+	fmt.Print(ct.RenderHeader())
+	for batch := range results {
+		for _, r := range batch {
+			ct.AddRow(r.Host, r.Status)
+		}
+		fmt.Print(ct.RenderRows())   // just the rows added since the last call
+		fmt.Println(summary(batch))
+	}
+	fmt.Print(ct.RenderEnd())
+
+The layout is frozen by the first piece rendered, from the rows the table has at the time (add a first batch of rows, or
+set MinWidth, to leave room), values in later rows too wide for their column are cut short. Rows are rendered in the
+order they're added. RenderEnd() closes the frame (and adds any Footer) and unfreezes the layout, so the table can be
+rendered again from the start.
+
+The pieces are what Display() would print - indent, line prefix and suffix, frame and all - with colors kept if the table's
+output is a terminal.
+*/
+
+// RenderHeader returns the lines that go above the rows - the top of the frame (or the title), and the header lines.
+func (ct *Table) RenderHeader() string {
+
+	lay := ct.frozenLayout()
+	cols := lay.columns()
+
+	return ct.captured(func() {
+		ct.printTop(true, lay, cols, ct.framer(lay, cols))
+	})
+}
+
+// RenderRows returns the lines of the rows added since the last RenderRows() call (all of them the first time).
+func (ct *Table) RenderRows() string {
+
+	frozen := ct.frozenLayout()
+	cols := frozen.columns()
+
+	lay := ct.computeLayout()
+	lay.widths, lay.hidden, lay.fixed = frozen.widths, frozen.hidden, true
+
+	// just the lines of the new rows (annotations included), and the separator lines in front of them
+	start := ct.lineCount()
+	if ct.renderedRows < ct.RowCount {
+		start, _ = ct.rowLines(ct.renderedRows)
+	}
+	order := []int{}
+	for i := 0; i < lay.lines; i++ {
+		l := lay.lineAt(i)
+		r, annotation := isAnnotationLine(l)
+		if lay.sourceLine(l) >= start || annotation && r >= ct.renderedRows || isSeparatorLine(l) && i+1 < lay.lines && lay.sourceLine(lay.lineAt(i+1)) >= start {
+			order = append(order, l)
+		}
+	}
+	lay.lineOrder, lay.lines = order, len(order)
+
+	continued := ct.renderedRows > 0
+	ct.renderedRows = ct.RowCount
+
+	return ct.captured(func() {
+		ct.printLines(lay, cols, ct.framer(lay, cols), continued)
+	})
+}
+
+// RenderEnd returns the lines that go below the rows - the footer and the bottom of the frame, "" if there are neither -
+// and unfreezes the layout.
+func (ct *Table) RenderEnd() string {
+
+	frozen := ct.frozenLayout()
+	cols := frozen.columns()
+
+	lay := ct.computeLayout()
+	lay.widths, lay.hidden, lay.fixed = frozen.widths, frozen.hidden, true
+
+	ct.renderLayout, ct.renderedRows = nil, 0
+
+	return ct.captured(func() {
+		f := ct.framer(lay, cols)
+		ct.printFooter(lay, cols, f)
+		ct.printBottom(f)
+		ct.printLegend(lay)
+	})
+}
+
+// frozenLayout returns the layout the pieces are rendered with, working it out the first time
+func (ct *Table) frozenLayout() layout {
+
+	if ct.renderLayout == nil {
+		lay := ct.computeLayout()
+		ct.renderLayout = &lay
+	}
+
+	return *ct.renderLayout
+}
+
+// captured returns what print prints, colored as it would be on the table's output
+func (ct *Table) captured(print func()) string {
+
+	var sb strings.Builder
+	out, force := ct.output, ct.ForceColor
+	ct.ForceColor = ct.colorEnabled(ct.writer())
+	ct.output = &sb
+	print()
+	ct.output, ct.ForceColor = out, force
+
+	return sb.String()
+}
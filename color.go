@@ -0,0 +1,46 @@
+package ctable
+
+import (
+	"io"
+	"os"
+)
+
+/*
+Plain output when piped.
+
+Colors and styles (HeaderColor, ErrorColor, Style etc.) only make sense on a terminal, in a file or another program's
+input the escape codes are just noise. So output that isn't going to a terminal is printed as plain text, as it is
+when the NO_COLOR environment variable is set (see https://no-color.org). ForceColor keeps the escape codes regardless,
+e.g. for a tool's --color=always flag or when piping into less -R.
+*/
+
+// colorEnabled reports whether output to out keeps its ANSI escape codes
+func (ct *Table) colorEnabled(out io.Writer) bool {
+
+	if tw, ok := out.(*targetWriter); ok && tw.color != colorAuto {
+		return tw.color == colorAlways
+	}
+	if ct.ForceColor {
+		return true
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return isTerminalWriter(out)
+}
+
+// isTerminalWriter reports whether out is a terminal
+func isTerminalWriter(out io.Writer) bool {
+	f, ok := outputFile(out)
+	return ok && isTerminal(f)
+}
+
+// outputFile returns the file output to out ends up in, if it's written straight to one
+func outputFile(out io.Writer) (*os.File, bool) {
+	if tw, ok := out.(*targetWriter); ok {
+		out = tw.w
+	}
+	f, ok := out.(*os.File)
+	return f, ok
+}
@@ -0,0 +1,276 @@
+package ctable
+
+import (
+	"encoding/json"
+	"io"
+)
+
+/*
+Render configuration.
+
+A TableConfig captures how a table is laid out and rendered (column names, widths, justification, truncation,
+display settings, and theme) separately from its data, so a standard layout can be saved as JSON, versioned,
+and applied to tables across tools:
+
+	cfg := ct.Config()
+	cfg.Write(f)
+	...
+	cfg, err := ctable.ReadConfig(f)
+	ct := ctable.NewTableFromConfig(cfg)   // or ct.ApplyConfig(cfg) for an existing table
+
+Every setting in a config is optional - ApplyConfig() applies the ones a config has and leaves the table's own value for
+the rest, so a hand written config only needs what it changes:
+
+	{"title": "Nodes", "columns": [{"name": "Size", "justification": "right"}]}
+
+Left out of configs: settings that depend on the program rather than the layout (KeepRawValues, ShowProgress,
+StableWidths, ForceColor, Logger, Trace), and what's set by calling methods rather than by fields - column styles and
+row styles, abbreviations, annotations, escapers, sorting, output encoding, and Indent(). Column widths fixed with
+SetColumnWidths() (or kept by StableWidths) are in the config as each column's width.
+*/
+
+type TableConfig struct {
+	Columns []ColumnConfig `json:"columns"`
+
+	// settings left out of the config (nil) are left as they are by ApplyConfig()
+	ErrorPrefix           *string `json:"errorPrefix,omitempty"`
+	ErrorColor            *string `json:"errorColor,omitempty"`
+	EmptyValue            *string `json:"emptyValue,omitempty"`
+	EmptyMessage          *string `json:"emptyMessage,omitempty"`
+	StackWhenWide         *bool   `json:"stackWhenWide,omitempty"`
+	StackKeyColumn        *string `json:"stackKeyColumn,omitempty"`
+	TruncateWithinWidth   *bool   `json:"truncateWithinWidth,omitempty"`
+	MaxWidth              *int    `json:"maxWidth,omitempty"`
+	FitToWidth            *bool   `json:"fitToWidth,omitempty"`
+	FlushRight            *bool   `json:"flushRight,omitempty"`
+	ASCII                 *bool   `json:"ascii,omitempty"`
+	Title                 *string `json:"title,omitempty"`
+	PipedFormat           *string `json:"pipedFormat,omitempty"`
+	Footer                *string `json:"footer,omitempty"`
+	HideEmptyColumns      *bool   `json:"hideEmptyColumns,omitempty"`
+	LinePrefix            *string `json:"linePrefix,omitempty"`
+	LineSuffix            *string `json:"lineSuffix,omitempty"`
+	PadLastColumn         *bool   `json:"padLastColumn,omitempty"`
+	Paginate              *bool   `json:"paginate,omitempty"`
+	AbbreviationLegend    *bool   `json:"abbreviationLegend,omitempty"`
+	Verbose               *bool   `json:"verbose,omitempty"`
+	SortIndicators        *bool   `json:"sortIndicators,omitempty"`
+	SeparateMultilineRows *bool   `json:"separateMultilineRows,omitempty"`
+	MultilineRule         *string `json:"multilineRule,omitempty"`
+	GroupColumn           *string `json:"groupColumn,omitempty"`
+	GroupRule             *string `json:"groupRule,omitempty"`
+	Theme                 *Theme  `json:"theme,omitempty"`
+}
+
+type ColumnConfig struct {
+	Name string `json:"name"`
+
+	// settings left out of the config (nil) are left as they are by ApplyConfig()
+	Width               *int    `json:"width,omitempty"` // fixed width (see SetColumnWidths()), 0 for sized to the values
+	TruncateAt          *int    `json:"truncateAt,omitempty"`
+	Justification       *string `json:"justification,omitempty"`
+	HeaderJustification *string `json:"headerJustification,omitempty"`
+	Precision           *int    `json:"precision,omitempty"`
+	MinWidth            *int    `json:"minWidth,omitempty"`
+	PadChar             *string `json:"padChar,omitempty"` // "" for a space
+	Ditto               *bool   `json:"ditto,omitempty"`
+	DittoMark           *string `json:"dittoMark,omitempty"`
+	Merge               *bool   `json:"merge,omitempty"`
+	SortAs              *string `json:"sortAs,omitempty"`
+	Mask                *string `json:"mask,omitempty"`
+	Wrap                *bool   `json:"wrap,omitempty"`
+	WrapMarker          *string `json:"wrapMarker,omitempty"`
+	FitPriority         *int    `json:"fitPriority,omitempty"`
+}
+
+// Config returns the table's current render configuration.
+func (ct *Table) Config() TableConfig {
+
+	theme := ct.Theme
+	cfg := TableConfig{
+		ErrorPrefix:           copyOf(&ct.ErrorPrefix),
+		ErrorColor:            copyOf(&ct.ErrorColor),
+		EmptyValue:            copyOf(&ct.EmptyValue),
+		EmptyMessage:          copyOf(&ct.EmptyMessage),
+		StackWhenWide:         copyOf(&ct.StackWhenWide),
+		StackKeyColumn:        copyOf(&ct.StackKeyColumn),
+		TruncateWithinWidth:   copyOf(&ct.TruncateWithinWidth),
+		MaxWidth:              copyOf(&ct.MaxWidth),
+		FitToWidth:            copyOf(&ct.FitToWidth),
+		FlushRight:            copyOf(&ct.FlushRight),
+		ASCII:                 copyOf(&ct.ASCII),
+		Title:                 copyOf(&ct.Title),
+		PipedFormat:           copyOf(&ct.PipedFormat),
+		Footer:                copyOf(&ct.Footer),
+		HideEmptyColumns:      copyOf(&ct.HideEmptyColumns),
+		LinePrefix:            copyOf(&ct.LinePrefix),
+		LineSuffix:            copyOf(&ct.LineSuffix),
+		PadLastColumn:         copyOf(&ct.PadLastColumn),
+		Paginate:              copyOf(&ct.Paginate),
+		AbbreviationLegend:    copyOf(&ct.AbbreviationLegend),
+		Verbose:               copyOf(&ct.Verbose),
+		SortIndicators:        copyOf(&ct.SortIndicators),
+		SeparateMultilineRows: copyOf(&ct.SeparateMultilineRows),
+		MultilineRule:         copyOf(&ct.MultilineRule),
+		GroupColumn:           copyOf(&ct.GroupColumn),
+		GroupRule:             copyOf(&ct.GroupRule),
+		Theme:                 &theme,
+	}
+
+	for i, col := range ct.Columns {
+		cc := ColumnConfig{
+			Name:                col.Name,
+			TruncateAt:          copyOf(&col.truncateAt),
+			Justification:       copyOf((*string)(&col.Justification)),
+			HeaderJustification: copyOf((*string)(&col.HeaderJustification)),
+			Precision:           copyOf(&col.Precision),
+			MinWidth:            copyOf(&col.MinWidth),
+			Ditto:               copyOf(&col.Ditto),
+			DittoMark:           copyOf(&col.DittoMark),
+			Merge:               copyOf(&col.Merge),
+			SortAs:              copyOf(&col.SortAs),
+			Mask:                copyOf(&col.Mask),
+			Wrap:                copyOf(&col.Wrap),
+			WrapMarker:          copyOf(&col.WrapMarker),
+			FitPriority:         copyOf(&col.FitPriority),
+		}
+		padChar := padCharString(col.PadChar)
+		cc.PadChar = &padChar
+		if ct.lockedWidths != nil {
+			cc.Width = copyOf(&ct.lockedWidths[i])
+		}
+		cfg.Columns = append(cfg.Columns, cc)
+	}
+
+	return cfg
+}
+
+// ApplyConfig applies cfg to the table - the settings it has, anything it leaves out is left as it is. Column settings
+// are matched up by column name, columns the config doesn't mention (and config columns the table doesn't have) are left alone.
+func (ct *Table) ApplyConfig(cfg TableConfig) {
+
+	applySetting(&ct.ErrorPrefix, cfg.ErrorPrefix)
+	applySetting(&ct.ErrorColor, cfg.ErrorColor)
+	applySetting(&ct.EmptyValue, cfg.EmptyValue)
+	applySetting(&ct.EmptyMessage, cfg.EmptyMessage)
+	applySetting(&ct.StackWhenWide, cfg.StackWhenWide)
+	applySetting(&ct.StackKeyColumn, cfg.StackKeyColumn)
+	applySetting(&ct.TruncateWithinWidth, cfg.TruncateWithinWidth)
+	applySetting(&ct.MaxWidth, cfg.MaxWidth)
+	applySetting(&ct.FitToWidth, cfg.FitToWidth)
+	applySetting(&ct.FlushRight, cfg.FlushRight)
+	applySetting(&ct.ASCII, cfg.ASCII)
+	applySetting(&ct.Title, cfg.Title)
+	applySetting(&ct.PipedFormat, cfg.PipedFormat)
+	applySetting(&ct.Footer, cfg.Footer)
+	applySetting(&ct.HideEmptyColumns, cfg.HideEmptyColumns)
+	applySetting(&ct.LinePrefix, cfg.LinePrefix)
+	applySetting(&ct.LineSuffix, cfg.LineSuffix)
+	applySetting(&ct.PadLastColumn, cfg.PadLastColumn)
+	applySetting(&ct.Paginate, cfg.Paginate)
+	applySetting(&ct.AbbreviationLegend, cfg.AbbreviationLegend)
+	applySetting(&ct.Verbose, cfg.Verbose)
+	applySetting(&ct.SortIndicators, cfg.SortIndicators)
+	applySetting(&ct.SeparateMultilineRows, cfg.SeparateMultilineRows)
+	applySetting(&ct.MultilineRule, cfg.MultilineRule)
+	applySetting(&ct.GroupColumn, cfg.GroupColumn)
+	applySetting(&ct.GroupRule, cfg.GroupRule)
+	applySetting(&ct.Theme, cfg.Theme)
+
+	for _, cc := range cfg.Columns {
+		i := ct.columnIndex(cc.Name)
+		if i < 0 {
+			continue
+		}
+		col := &ct.Columns[i]
+		if cc.TruncateAt != nil {
+			col.setTruncateAt(*cc.TruncateAt)
+		}
+		if cc.Justification != nil {
+			col.Justification = Justification(*cc.Justification)
+		}
+		if cc.HeaderJustification != nil {
+			col.HeaderJustification = Justification(*cc.HeaderJustification)
+		}
+		applySetting(&col.Precision, cc.Precision)
+		applySetting(&col.MinWidth, cc.MinWidth)
+		applySetting(&col.Ditto, cc.Ditto)
+		applySetting(&col.DittoMark, cc.DittoMark)
+		applySetting(&col.Merge, cc.Merge)
+		applySetting(&col.SortAs, cc.SortAs)
+		applySetting(&col.Mask, cc.Mask)
+		applySetting(&col.Wrap, cc.Wrap)
+		applySetting(&col.WrapMarker, cc.WrapMarker)
+		applySetting(&col.FitPriority, cc.FitPriority)
+		if cc.PadChar != nil {
+			col.PadChar = 0
+			for _, r := range *cc.PadChar {
+				col.PadChar = r
+				break
+			}
+		}
+		if cc.Width != nil {
+			if ct.lockedWidths == nil {
+				ct.lockedWidths = make([]int, ct.ColumnCount)
+			}
+			ct.lockedWidths[i] = *cc.Width
+		}
+	}
+
+	ct.windowLayout = nil
+}
+
+// applySetting sets *setting to *value, if the config has a value
+func applySetting[T any](setting *T, value *T) {
+	if value != nil {
+		*setting = *value
+	}
+}
+
+// copyOf returns a pointer to a copy of *p, nil for nil
+func copyOf[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func padCharString(r rune) string {
+	if r == 0 {
+		return ""
+	}
+	return string(r)
+}
+
+// NewTableFromConfig creates a new (empty) table with the columns and settings in cfg.
+func NewTableFromConfig(cfg TableConfig) Table {
+
+	columns := []Column{}
+	for _, cc := range cfg.Columns {
+		truncateAt := 0
+		if cc.TruncateAt != nil {
+			truncateAt = *cc.TruncateAt
+		}
+		columns = append(columns, NewColumn(cc.Name, truncateAt))
+	}
+
+	ct := NewTable(columns)
+	ct.ApplyConfig(cfg)
+
+	return ct
+}
+
+// Write writes the config to w as (indented) JSON.
+func (cfg TableConfig) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cfg)
+}
+
+// ReadConfig reads a config written by TableConfig.Write() (or by hand) from r.
+func ReadConfig(r io.Reader) (TableConfig, error) {
+	var cfg TableConfig
+	err := json.NewDecoder(r).Decode(&cfg)
+	return cfg, err
+}
@@ -0,0 +1,51 @@
+package ctable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+
+	ct := testTable()
+	ct.Title = "Files"
+	ct.SetColumnWidths(8, 0, 0)
+
+	var buf bytes.Buffer
+	if err := ct.Config().Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ReadConfig(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configured := NewTableFromConfig(cfg)
+	configured.AddRow("a.txt", 12, "plain text file")
+	configured.AddRow("b, c", []string{"3", "4"}, `say "hi"`)
+	configured.AddRow("d|e", nil, "")
+
+	want, _ := ct.Render()
+	if got, _ := configured.Render(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyConfigLeavesOut(t *testing.T) {
+
+	ct := testTable()
+	ct.ApplyConfig(TableConfig{Columns: []ColumnConfig{{Name: "Name", Width: new(int)}}})
+
+	if j := ct.Columns[1].Justification; j != JustifyRight {
+		t.Errorf("Size justification: got %q, want %q", j, JustifyRight)
+	}
+	if ct.Title != "" {
+		t.Errorf("Title: got %q", ct.Title)
+	}
+
+	left := "left"
+	ct.ApplyConfig(TableConfig{Columns: []ColumnConfig{{Name: "Size", Justification: &left}}})
+	if j := ct.Columns[1].Justification; j != JustifyLeft {
+		t.Errorf("Size justification: got %q, want %q", j, JustifyLeft)
+	}
+}
@@ -0,0 +1,70 @@
+package ctable
+
+/*
+Rows from a channel.
+
+For collect-and-display pipelines where worker goroutines produce the rows, ConsumeRows() adds rows as they're sent
+on a channel until it's closed, so the workers never touch the table (which isn't safe for concurrent use) themselves:
+
+	rows := make(chan []string)
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			rows <- []string{host, ping(host)}
+		}(host)
+	}
+	go func() { wg.Wait(); close(rows) }()
+
+	ct.ConsumeRows(rows)
+	ct.Display(true)
+
+ConsumeRowsStreaming() prints each row the moment it arrives as well. There's no seeing the rows to come, so the column
+widths are settled by the first row (and the column names and MinWidth, set MinWidth to leave room), later values too wide
+for their column are cut short. The table ends up holding every row either way, so it can be displayed in full afterwards.
+*/
+
+// ConsumeRows adds the rows received on ch to the table, returning once ch is closed.
+func (ct *Table) ConsumeRows(ch <-chan []string) {
+	for fields := range ch {
+		ct.addStrings(fields)
+	}
+}
+
+// ConsumeRowsStreaming is ConsumeRows() printing each row as it arrives (see consume.go).
+func (ct *Table) ConsumeRowsStreaming(showHeaders bool, ch <-chan []string) {
+
+	// the rows are printed from a copy holding just the row at hand (and they're progress enough)
+	defer func(show bool) { ct.ShowProgress = show }(ct.ShowProgress)
+	ct.ShowProgress = false
+	printer := ct.emptyCopy()
+	printer.displaySource(showHeaders, RowSourceFunc(func() ([]string, bool) {
+		fields, ok := <-ch
+		if ok {
+			ct.addStrings(fields)
+		}
+		return fields, ok
+	}), 1)
+}
+
+// emptyCopy returns a table with the same columns and settings but none of the rows
+func (ct *Table) emptyCopy() *Table {
+
+	cp := *ct
+	cp.Columns = append([]Column(nil), ct.Columns...)
+	cp.cells, cp.columnCells, cp.columnar = nil, nil, false
+	cp.rowStarts, cp.rawValues, cp.parents, cp.collapsed, cp.sortOrder = nil, nil, nil, nil, nil
+	cp.KeepRawValues = false
+	cp.rowAddedHandlers = nil
+	cp.cellJustifications = nil
+	cp.rowStyles, cp.cellStyles = nil, nil
+	cp.annotations = nil
+	cp.lazyCells = nil
+	cp.warnings = nil
+	cp.windowLayout = nil
+	cp.renderLayout, cp.renderedRows = nil, 0
+	cp.RowCount = 0
+
+	return &cp
+}
@@ -0,0 +1,123 @@
+package ctable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+CSV output.
+
+WriteCSV() writes the table's data as CSV, with the options picky downstream tools tend to need:
+
+	err := ct.WriteCSV(f, ctable.CSVOptions{ShowHeaders: true, Delimiter: ';', Quote: "all", CRLF: true})
+
+Quote policies:
+
+	"minimal"     (the default) only fields containing the delimiter, a quote, or a line break are quoted
+	"all"         every field is quoted
+	"nonnumeric"  every field that isn't a number is quoted
+	"none"        nothing is quoted, it's up to the data not to contain delimiters or line breaks
+
+Quotes inside quoted fields are doubled. Multiline values are written as a single field with the lines separated by
+line breaks, or with ExplodeMultiline as extra records, one per line (the other fields of those records are blank),
+for tools that can't cope with line breaks inside fields.
+
+Values are written without any ANSI styling, in the sorted order (see SortBy(), the order rows were added if the
+table isn't sorted), including rows in collapsed groups.
+*/
+
+type CSVOptions struct {
+	ShowHeaders      bool
+	Delimiter        rune   // between fields, 0 for ','
+	Quote            string // "minimal" ("" too), "all", "nonnumeric", or "none"
+	CRLF             bool   // end records with \r\n rather than \n
+	ExplodeMultiline bool   // write multiline values as a record per line rather than line breaks within a field
+}
+
+// WriteCSV writes the table to w as CSV.
+func (ct *Table) WriteCSV(w io.Writer, opts CSVOptions) error {
+
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	quote := strings.ToLower(opts.Quote)
+	switch quote {
+	case "", "minimal", "all", "nonnumeric", "none":
+	default:
+		return fmt.Errorf("CONSOLETABLE: unknown CSV quote policy %q", opts.Quote)
+	}
+	eol := "\n"
+	if opts.CRLF {
+		eol = "\r\n"
+	}
+
+	bw := bufio.NewWriter(w)
+
+	writeRecord := func(fields []string) {
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteRune(delimiter)
+			}
+			bw.WriteString(csvField(ct.escape("csv", stripANSI(field)), delimiter, quote))
+		}
+		bw.WriteString(eol)
+	}
+
+	if opts.ShowHeaders {
+		names := make([]string, ct.ColumnCount)
+		for c, col := range ct.Columns {
+			names[c] = col.Name
+		}
+		writeRecord(names)
+	}
+
+	for pos := 0; pos < ct.RowCount; pos++ {
+		r := ct.OriginalIndex(pos)
+		if !opts.ExplodeMultiline {
+			writeRecord(ct.OutputRow(r))
+			continue
+		}
+		// a record per display line, with the padding lines under shorter multiline values dropped
+		first, _ := ct.rowLines(r)
+		lines := 1
+		for _, value := range ct.Row(r) {
+			if n := strings.Count(value, "\n") + 1; n > lines {
+				lines = n
+			}
+		}
+		for l := first; l < first+lines; l++ {
+			writeRecord(ct.outputLine(l))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// csvField returns field as written per the quote policy
+func csvField(field string, delimiter rune, quote string) string {
+
+	needsQuotes := false
+	switch quote {
+	case "all":
+		needsQuotes = true
+	case "nonnumeric":
+		_, err := strconv.ParseFloat(field, 64)
+		needsQuotes = err != nil
+	case "none":
+		return field
+	}
+	if !needsQuotes {
+		needsQuotes = strings.ContainsRune(field, delimiter) || strings.ContainsAny(field, "\"\r\n")
+	}
+
+	if !needsQuotes {
+		return field
+	}
+
+	return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+}
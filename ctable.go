@@ -12,19 +12,63 @@ Notable features:
 */
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"reflect"
 	"strconv"
-	"strings"
-	"unicode/utf8"
+	"time"
 )
 
 type Column struct {
 	Name               string
 	truncateAt         int
-	Justification      string
+	Justification      Justification // JustifyLeft (default, see SetDefaults()), JustifyRight, JustifyCenter, or JustifyDecimal (see justify.go)
 	truncationRequired bool
 	maxLength          int
+
+	// number of decimal places float values added to this column are displayed with, -1 (the default) uses as many as needed
+	Precision int
+
+	// the column is always displayed at least this wide, even when its values are all shorter (0 for no minimum)
+	MinWidth int
+
+	// kind of values in the column - "number", "bool", "date", or "" for text, as worked out by InferTypes()
+	Type string
+
+	// character values are padded out to the column width with, 0 for a space (e.g. '.' for "Name ........ value" leader lines)
+	PadChar rune
+
+	// show DittoMark (e.g. `"`, or "" for a blank) instead of a value that's the same as the one in the row above (see ditto.go)
+	Ditto     bool
+	DittoMark string
+
+	// merge runs of the same value down the column into a single cell, across the rules between rows (see merge.go)
+	Merge bool
+
+	// hide the column's values in output - "full", "last4", or "hash", "" to show them (see mask.go)
+	Mask string
+
+	// how values compare when rows are sorted by the column - "natural" (runs of digits as numbers), "number", "time" (RFC 3339,
+	// "2006-01-02 15:04:05", "2006-01-02", RFC 1123, Unix date, "15:04:05" and similar layouts), "" or "text" for plain text.
+	// Values that aren't numbers (or times) sort after the rest, ascending or descending (see sortorder.go)
+	SortAs string
+
+	// columns with a lower priority are narrowed first when fitting the table to the terminal with FitToWidth (see fit.go)
+	FitPriority int
+
+	// wrap values wider than truncateAt onto more lines at word boundaries rather than truncating them, with WrapMarker (e.g. "↳ ")
+	// at the start of each continuation line, see wrap.go
+	Wrap       bool
+	WrapMarker string
+
+	// justification of the column's name in the header, "" for left whatever the values' justification (see justify.go)
+	HeaderJustification Justification
+
+	// styles of the column's values and of its name in the header, on top of any row style (see style.go)
+	Style       Style
+	HeaderStyle Style
 }
 
 func NewColumn(name string, truncateAt int) Column {
@@ -37,25 +81,228 @@ func NewColumn(name string, truncateAt int) Column {
 	return Column{
 		Name:               name,
 		truncateAt:         truncateAt,
-		Justification:      "left",
-		truncationRequired: truncateAt > 0 && utf8.RuneCountInString(name) > truncateAt,
-		maxLength:          utf8.RuneCountInString(name),
+		Justification:      defaultJustification(),
+		truncationRequired: truncateAt > 0 && textWidth(name) > truncateAt,
+		maxLength:          textWidth(name),
+		Precision:          -1,
 	}
 }
 
+// setTruncateAt changes the column's truncation point after the fact, re-evaluating whether truncation is required
+func (col *Column) setTruncateAt(truncateAt int) {
+	col.truncateAt = truncateAt
+	col.truncationRequired = truncateAt > 0 && col.maxLength > truncateAt
+}
+
 type Table struct {
 	Columns     []Column
 	ColumnCount int
-	Rows        [][]interface{}
 	RowCount    int
+
+	// keep the original values passed to AddRow() (before conversion to strings) so they can be retrieved with RawValue(),
+	// off by default as it costs memory, must be set before rows are added
+	KeepRawValues bool
+
+	// error values added as fields are displayed as ErrorPrefix followed by the error text,
+	// colored with ErrorColor if set (an ANSI SGR code, e.g. "31" for red)
+	ErrorPrefix string
+	ErrorColor  string
+
+	// displayed in place of nil field values (nil errors etc.)
+	EmptyValue string
+
+	// displayed in place of a table with no rows, e.g. "No resources found.", "" to display just the header (see empty.go)
+	EmptyMessage string
+
+	// when set, Display() breaks a table wider than the terminal into stacked sub-tables that each fit,
+	// each starting with the column named StackKeyColumn (if set) - see paging.go
+	StackWhenWide  bool
+	StackKeyColumn string
+
+	// count the "..." added to values cut short as part of each column's truncateAt, rather than on top of it (see truncate.go)
+	TruncateWithinWidth bool
+
+	// separators, padding, and colors - see theme.go
+	Theme Theme
+
+	// width output has to fit in when laying out tables too wide for the screen (StackWhenWide etc.), 0 for the terminal width
+	MaxWidth int
+
+	// narrow the columns so the table fits MaxWidth (or the terminal width), cutting values short as needed (see fit.go)
+	FitToWidth bool
+
+	// stretch the table to MaxWidth (or the terminal width) so the last column sits against the right edge (see flush.go)
+	FlushRight bool
+
+	// use ASCII in place of the box drawing and other non ASCII characters used for decoration (tree guides etc.)
+	ASCII bool
+
+	// shown let into the top line of the frame when the theme has one (see frame.go), otherwise on a line of its own above the table
+	Title string
+
+	// "tsv", "json", or "plain" to have Display() write the table in that format instead when output isn't going to a terminal
+	// (so the same command works for people and scripts), "" to always display it (see pipe.go)
+	PipedFormat string
+
+	// keep colors and styles in output that isn't going to a terminal (see color.go)
+	ForceColor bool
+
+	// summary of each numeric column's values under the table - "range" (min, median, max) or "sparkline", "" for none (see footer.go)
+	Footer string
+
+	// leave out columns with nothing but empty values (or EmptyValue) when displaying the table
+	HideEmptyColumns bool
+
+	// text put at the start and end of every line printed (after any Indent()), e.g. "# " or "│ "
+	LinePrefix string
+	LineSuffix string
+
+	// pad the last column out to its width like the others, off by default so lines don't end in spaces (see trailing.go)
+	PadLastColumn bool
+
+	// where diagnostics go, nil for the package Logger (see SetLogger())
+	Logger Logger
+
+	// when set, every layout pass writes the measured widths and the width each column got to Trace (see trace.go)
+	Trace io.Writer
+
+	// on a terminal, display tables longer than the screen a screenful at a time with a "-- More --" prompt (see more.go)
+	Paginate bool
+
+	// list the abbreviations displayed under the table, see abbrev.go
+	AbbreviationLegend bool
+
+	// show the text attached to rows with Annotate() under them
+	Verbose bool
+
+	// mark the column the rows are sorted by in the header, see SetSortIndicator()
+	SortIndicators bool
+	sortIndicator  *sortIndicator
+
+	// row storage, see storage.go
+	cells       []string       // every field of every display line, line after line (ColumnCount per line)
+	columnCells [][]string     // ... or, with columnar storage, every field of each column in a slice per column
+	columnar    bool           // which of the two above is in use
+	rowStarts   []int          // index of the first display line of each row (rows with multiline values take up several lines)
+	rawValues   []interface{}  // original field values, ColumnCount per row (only when KeepRawValues is set)
+	parents     []int          // parent row of each row (-1 for none), only once a child row has been added (see tree.go)
+	collapsed   map[int]bool   // parent rows of collapsed groups
+	sortOrder   []int          // rows in sorted order (by index in the order they were added), nil when not sorted (see sort.go)
+	annotations map[int]string // text shown under each row with Verbose set (by row), see Annotate()
+
+	// short forms values are displayed as (by column), see Abbreviate()
+	abbreviations map[int]func(string) string
+
+	rowAddedHandlers []func(i int, row []string)
+
+	// keep a "loaded N rows…" line up to date on stderr while rows are added, erased when the table's printed (see progress.go)
+	ShowProgress  bool
+	progressSince time.Time // when the first row was added with ShowProgress set, zero before then
+	progressShown bool      // the progress line is on screen
+
+	// put a blank line after each row that takes up more than one line, or MultilineRule repeated across the table if set
+	SeparateMultilineRows bool
+	MultilineRule         string
+
+	// put a blank line between groups of rows with the same value in the column named GroupColumn,
+	// or GroupRule repeated across the table if set (see spacing.go)
+	GroupColumn string
+	GroupRule   string
+
+	// put in front of every line printed, see Indent()
+	indent string
+
+	// where printed lines go, nil for stdout (set while output is being captured, e.g. by RenderGoLiteral())
+	output io.Writer
+
+	// lazy field values not worked out yet (by display line*ColumnCount + column), see lazy.go
+	lazyCells map[int]CellProvider
+
+	// justification of cells that differ from their column (by display line*ColumnCount + column), see JustifyCell()
+	cellJustifications map[int]Justification
+
+	// struct type rows are added as, and the field (index) shown in each column, see AddStruct()
+	structType   reflect.Type
+	structFields [][]int
+
+	// see StyleRowsWhere(), StripeGroups(), StyleRow(), and StyleCell() (by row, and display line*ColumnCount + column)
+	rowStyleRules []rowStyleRule
+	stripe        *groupStripe
+	rowStyles     map[int]Style
+	cellStyles    map[int]Style
+
+	// encoding printed lines are transcoded to, nil for UTF-8 (see SetOutputEncoding())
+	encoding *charmap
+
+	// escaping that replaces the default for a format, by format (see SetEscaper())
+	escapers map[string]func(string) string
+
+	// values cut short by the last Display(), see Warnings()
+	warnings []Warning
+
+	// keep the widths the table's first displayed at for every display after, see stable.go
+	StableWidths bool
+	lockedWidths []int // widths set by StableWidths or SetColumnWidths() (0 for columns left to their values), nil if none
+
+	// widths columns are kept to at least while Watch() refreshes the table, so they don't jump about, nil otherwise
+	widthFloor []int
+
+	// layout cached by RenderWindow() so widths stay put from one window to the next, dropped when rows are added
+	windowLayout *layout
+
+	// layout frozen by the first of RenderHeader(), RenderRows(), RenderEnd(), and how many rows RenderRows() has rendered
+	renderLayout *layout
+	renderedRows int
 }
 
 func NewTable(columns []Column) Table {
-	return Table{
+
+	ct := Table{
 		Columns:     columns,
 		ColumnCount: len(columns),
-		Rows:        [][]interface{}{},
 		RowCount:    0,
+		ErrorPrefix: "ERR: ",
+		EmptyValue:  defaults.EmptyValue,
+		Theme:       defaultTheme(),
+	}
+
+	// CTABLE_* environment variables override the defaults (including any set with SetDefaults()), see env.go
+	ct.applyEnvironment()
+
+	return ct
+}
+
+// columnIndex returns the index of the column named name, or -1 if there isn't one
+func (ct *Table) columnIndex(name string) int {
+	for i, col := range ct.Columns {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// OnRowAdded registers fn to be called every time AddRow() adds a row, after the row has been stored.
+// i is the zero based index of the row in the order rows were added (a row with multiline values still counts as one row),
+// and row holds its field values with any multiline values joined by newlines. Lazy values (see lazy.go) are blank in
+// row - they aren't worked out just to be passed to fn, calling Row() from fn does work them out.
+func (ct *Table) OnRowAdded(fn func(i int, row []string)) {
+	ct.rowAddedHandlers = append(ct.rowAddedHandlers, fn)
+}
+
+// notify any OnRowAdded() callbacks about the row that was just added
+func (ct *Table) rowAdded() {
+
+	ct.showProgress()
+
+	if len(ct.rowAddedHandlers) == 0 {
+		return
+	}
+
+	row := ct.joinedRow(ct.RowCount-1, ct.storedCell)
+
+	for _, fn := range ct.rowAddedHandlers {
+		fn(ct.RowCount-1, row)
 	}
 }
 
@@ -67,13 +314,40 @@ func NewTable(columns []Column) Table {
 	ct.AddRow("string data", []string{"one", "two", "three", "four"}, "string data")
 
 	Its variadic, so any number of values in any mix of strings and string slices can be used (number of args has to match number of columns of course)
+
+	Values implementing encoding.TextMarshaler (net.IP, uuid.UUID, enum types etc.) are also accepted, their MarshalText() output is used as the field value.
+	Errors are accepted too and displayed per ErrorPrefix/ErrorColor, a nil value (e.g. a nil error) is displayed as EmptyValue.
+	Numeric values (ints and floats) are accepted and converted to strings, floats per the column's Precision.
+	A CellProvider (or func() string) is accepted too, it's called for the value when the value is first needed (see lazy.go).
 */
 func (ct *Table) AddRow(fields ...interface{}) {
 
 	if len(fields) != ct.ColumnCount {
-		log.Fatal("CONSOLETABLE: Cannot add a row of data with more, or fewer, fields than defined columns.")
+		ct.fatal("Cannot add a row of data with more, or fewer, fields than defined columns.")
+		return
+	}
+
+	raw := fields
+
+	// convert any other supported types to their string form up front, the rest of the logic only deals in string and []string
+	// (working on a copy so the caller's slice isn't modified when called as AddRow(slice...))
+	fields = append([]interface{}(nil), fields...)
+	for i := range fields {
+		f, err := ct.normalizeField(&ct.Columns[i], fields[i])
+		if err != nil {
+			ct.fatal(err.Error())
+			return
+		}
+		fields[i] = f
+	}
+
+	if ct.KeepRawValues {
+		ct.rawValues = append(ct.rawValues, raw...)
 	}
 
+	// lazy values are stored blank, and worked out when they're needed (see lazy.go)
+	providers := takeProviders(fields)
+
 	/*
 		Update max length values and truncation status stored with column defs.
 		Whether truncation *will* be required is stored with the column def so it can be used in display logic,
@@ -98,8 +372,8 @@ func (ct *Table) AddRow(fields ...interface{}) {
 
 		case string:
 
-			if ct.Columns[i].maxLength < utf8.RuneCountInString(v) {
-				ct.Columns[i].maxLength = utf8.RuneCountInString(v)
+			if ct.Columns[i].maxLength < textWidth(v) {
+				ct.Columns[i].maxLength = textWidth(v)
 			}
 			if ct.Columns[i].truncateAt > 0 && ct.Columns[i].maxLength > ct.Columns[i].truncateAt {
 				ct.Columns[i].truncationRequired = true
@@ -113,22 +387,26 @@ func (ct *Table) AddRow(fields ...interface{}) {
 
 			for _, str := range v {
 
-				if ct.Columns[i].maxLength < utf8.RuneCountInString(str) {
-					ct.Columns[i].maxLength = utf8.RuneCountInString(str)
+				if ct.Columns[i].maxLength < textWidth(str) {
+					ct.Columns[i].maxLength = textWidth(str)
 				}
 				if ct.Columns[i].truncateAt > 0 && ct.Columns[i].maxLength > ct.Columns[i].truncateAt {
 					ct.Columns[i].truncationRequired = true
 				}
 			}
-
-		default:
-			log.Fatal("CONSOLETABLE: You can add only string or []string types as individual fields to AddRow().")
 		}
 	}
 
+	ct.rowStarts = append(ct.rowStarts, ct.lineCount())
+	if providers != nil {
+		ct.storeProviders(ct.lineCount(), providers)
+	}
+
 	if !rowState.hasMultilineValue {
 		// add as normal, each field is an interface{} that's value IS a single string
-		ct.Rows = append(ct.Rows, fields)
+		for fi, f := range fields {
+			ct.appendCell(fi, f.(string))
+		}
 	} else {
 		// deal with multiline fields
 
@@ -143,8 +421,7 @@ func (ct *Table) AddRow(fields ...interface{}) {
 
 		for x := 0; x < longestMulti; x++ { // in context of the one 'row', this is the 'down' direction due to multiline values
 
-			// contruct slice to be used to append to ct.Rows
-			var tempFields []interface{}
+			// append the fields of this display line to the cell storage
 			for fi := 0; fi < ct.ColumnCount; fi++ { // ... and this is the 'across' direction
 
 				var isMLField bool
@@ -157,32 +434,84 @@ func (ct *Table) AddRow(fields ...interface{}) {
 				// first row special
 				if x == 0 {
 					if !isMLField {
-						tempFields = append(tempFields, fields[fi])
+						ct.appendCell(fi, fields[fi].(string))
 					} else {
-						tempFields = append(tempFields, fields[fi].([]string)[0])
+						ct.appendCell(fi, fields[fi].([]string)[0])
 						rowState.mlTracker[fi]++
 					}
 				} else {
 					// not first row, the rest (to support multiline in the table output, empty strings have to be inserted into all the other fields that are not multiline, *after* the first line)
 					if !isMLField {
-						tempFields = append(tempFields, "") // blanks in cells/fields next to cells/fields with multilines after first value is displayed with the rest of the non multiline value row
+						ct.appendCell(fi, "") // blanks in cells/fields next to cells/fields with multilines after first value is displayed with the rest of the non multiline value row
 					} else {
 						// there can be multiple multiline fields with varying lengths, only add/write if there are more values for THIS field (or we'll blow an index bounds)
 						if len(fields[fi].([]string)) > rowState.mlTracker[fi] {
-							tempFields = append(tempFields, fields[fi].([]string)[rowState.mlTracker[fi]])
+							ct.appendCell(fi, fields[fi].([]string)[rowState.mlTracker[fi]])
 							rowState.mlTracker[fi]++ // keeping track of which item in the multiline list has been handled and which is up next
 						} else {
 							// this field is done but others may still have more to go, add the blank - total iterations controlled by length of *longest* multiline field of the row, has to be
-							tempFields = append(tempFields, "")
+							ct.appendCell(fi, "")
 						}
 					}
 				}
 			}
+		}
+	}
 
-			//  tempFields should have been appropriately built now to add as a 'row' in the context of a row to be displayed across the screen
-			ct.Rows = append(ct.Rows, tempFields)
+	ct.RowCount++
+	if ct.parents != nil {
+		ct.parents = append(ct.parents, -1) // a root, AddChildRow() sets the parent
+	}
+	ct.windowLayout = nil
+	ct.rowAdded()
+}
+
+// normalizeField converts a field value of any supported type other than string or []string to a string,
+// the error says why if the value can't be used as a field.
+func (ct *Table) normalizeField(col *Column, field interface{}) (interface{}, error) {
+
+	switch v := field.(type) {
+
+	case nil:
+		return ct.EmptyValue, nil
+
+	case string:
+		return v, nil
+
+	case CellProvider:
+		return v, nil
+
+	case func() string:
+		return CellProviderFunc(v), nil
+
+	case []string:
+		// an empty list still takes up a line
+		if len(v) == 0 {
+			return "", nil
+		}
+		return v, nil
+
+	case error:
+		return colorize(ct.ErrorPrefix+v.Error(), ct.ErrorColor), nil
+
+	case float64:
+		return strconv.FormatFloat(v, 'f', col.Precision, 64), nil
+
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', col.Precision, 32), nil
+
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, errors.New("MarshalText() failed for a field passed to AddRow(): " + err.Error())
 		}
+		return string(text), nil
 	}
+
+	return nil, errors.New("You can add only string, []string, error, numeric, encoding.TextMarshaler, or CellProvider types as individual fields to AddRow().")
 }
 
 //func (t *Table) AddRow(fields ...string) {
@@ -209,101 +538,372 @@ func (ct *Table) AddRow(fields ...interface{}) {
 //	ct.Rows = append(ct.Rows, fields)
 //}
 
-func (ct *Table) Display(showHeaders bool) {
+// layout holds everything worked out up front from the full data set that's needed to format individual lines
+type layout struct {
+	decimals []decimalLayout
+	widths   []int
 
-	processedRows := []string{}
+	// display order of rows and display lines, when it differs from the order they were added (nil otherwise),
+	// and how many of each are displayed (rows in collapsed groups aren't, separator and annotation lines are extra)
+	rowOrder  []int
+	lineOrder []int
+	rows      int
+	lines     int
 
-	for _, row := range ct.Rows {
-		// for each row
-		rowStr := ""
-		for i, col := range ct.Columns {
-			// for each field - build row string including padding for columnar output, justification, and any truncation per column defs
-			fieldData := row[i].(string) // to support multiline values, interfaces were used, at this point each field item should be a single string
+	prefixes  []string       // text to put in front of the first field of each display line (tree guides), nil if none
+	stems     []string       // tree guides carried down past the first line of each row (by row), nil with prefixes
+	summaries map[int]string // display lines replaced by a summary (collapsed groups)
 
-			// truncate field value?
-			if col.truncationRequired && utf8.RuneCountInString(fieldData) > col.truncateAt {
-				fieldData = fieldData[:col.truncateAt] + "..."
-			}
+	// values of Wrap columns wrapped to their width (see wrap.go), by line * ColumnCount + column - on the row's own lines and
+	// on the wrap lines added after them, numbered on from the lines stored (wrapBase), each with the first line of its row
+	wrapped     map[int]string
+	wrapLines   map[int][]int // wrap lines of each row (by row)
+	wrapSources []int
+	wrapBase    int
 
-			// create format string that will be used for column width and justification
-			var formatString string
-			var justCode string // used inside format string
+	lineStyles []Style // style of each display line (see StyleRowsWhere()), nil if none
 
-			if col.Justification == "left" {
-				justCode = "%-"
-			} else {
-				justCode = "%"
-			}
+	hidden []bool // columns left out (see HideEmptyColumns), nil if none
 
-			if col.truncationRequired {
-				formatString = justCode + strconv.Itoa(col.truncateAt+3) + "v" // +3 for the ... added when truncated
-			} else {
-				formatString = justCode + strconv.Itoa(col.maxLength) + "v"
-			}
+	flushed []bool // columns right justified against the right edge (see FlushRight), nil if none
 
-			// padding between columns, prepend a space to all but the first column
-			if i == 0 {
-				rowStr += fmt.Sprintf(formatString, fieldData)
-			} else {
-				rowStr += " " + fmt.Sprintf(formatString, fieldData)
-			}
-		} // END for each column
+	widthReasons []string // what decided each column's width, the last step to change it (see trace.go)
 
-		processedRows = append(processedRows, rowStr)
-	} // END for each row
+	footer [][]string // footer lines (see Footer), a field per column, nil if none
 
-	if showHeaders {
-		headerStr := ""
-		headerSeparator := ""
+	fixed bool // widths were settled before the rows were seen (see DisplaySource(), stable.go), values too wide are cut short
 
-		for i := range ct.Columns { // note: this format of 'range' is pointer rather than value, as we need to modify original object
+	dittos map[int]string // fields shown as a ditto mark instead (see Column.Ditto), by line * ColumnCount + column, nil if none
 
-			col := ct.Columns[i] // <- still pointer format for range, but assigning to var as it was already used throughout
+	gap       string // text between columns (per the theme)
+	headerGap string // ... and in the header lines
+}
 
-			// did we truncate? if so header needs to account for that
-			if col.truncationRequired {
+func (ct *Table) computeLayout() layout {
 
-				// padding between columns, prepend space to all but first column
-				if i == 0 {
-					headerSeparator += strings.Repeat("=", col.truncateAt+3) // +3 to account for the '...'
-				} else {
-					headerSeparator += " " + strings.Repeat("=", col.truncateAt+3) // +3 to account for the '...'
-				}
+	lay := layout{
+		rows:      ct.RowCount,
+		lines:     ct.lineCount(),
+		gap:       ct.Theme.gap(ct.separator(ct.Theme.ColumnSeparator), true),
+		headerGap: ct.Theme.gap(ct.separator(ct.Theme.ColumnSeparator), false),
+	}
 
-				// truncate column name also?
-				if utf8.RuneCountInString(col.Name) > col.truncateAt {
-					// padding between columns, prepend space to all but first column
-					if i == 0 {
-						headerStr += fmt.Sprintf("%-"+strconv.Itoa(col.truncateAt+3)+"v", col.Name[:col.truncateAt]+"...")
-					} else {
-						headerStr += " " + fmt.Sprintf("%-"+strconv.Itoa(col.truncateAt+3)+"v", col.Name[:col.truncateAt]+"...")
-					}
-				} else {
-					// padding between columns, prepend a space to all but first column
-					if i == 0 {
-						headerStr += fmt.Sprintf("%-"+strconv.Itoa(col.truncateAt+3)+"v", col.Name)
-					} else {
-						headerStr += " " + fmt.Sprintf("%-"+strconv.Itoa(col.truncateAt+3)+"v", col.Name)
-					}
-				}
+	ct.treeLayout(&lay)
+	if lay.rowOrder == nil && ct.sortOrder != nil {
+		lay.rowOrder = ct.sortedRows()
+	}
 
-			} else {
-				// padding between columns, prepend a space to all but the first column
-				if i == 0 {
-					headerStr += fmt.Sprintf("%-"+strconv.Itoa(col.maxLength)+"v", col.Name)
-					headerSeparator += strings.Repeat("=", col.maxLength)
-				} else {
-					headerStr += " " + fmt.Sprintf("%-"+strconv.Itoa(col.maxLength)+"v", col.Name)
-					headerSeparator += " " + strings.Repeat("=", col.maxLength)
+	// any lazy values displayed have to be worked out before the columns are sized
+	ct.resolveDisplayed(lay)
+	lay.decimals = ct.decimalLayouts()
+	lay.widths, lay.widthReasons = ct.columnWidths(lay.decimals)
+
+	ct.styleLayout(&lay)
+
+	if lay.rowOrder != nil {
+		lay.rows = len(lay.rowOrder)
+		ct.orderLines(&lay)
+	}
+
+	// the first column has to be wide enough for its values with their prefixes
+	if lay.prefixes != nil {
+		for _, l := range lay.lineOrder {
+			prefix := lay.prefixes[l]
+			width := textWidth(prefix) + textWidth(ct.displayedCell(l, 0))
+			if ct.Columns[0].truncationRequired && textWidth(ct.displayedCell(l, 0)) > ct.Columns[0].truncateAt {
+				width = textWidth(prefix) + ct.truncatedWidth(0)
+				if ct.Columns[0].Wrap {
+					width = textWidth(prefix) + ct.Columns[0].truncateAt
 				}
 			}
+			if width > lay.widths[0] {
+				lay.widths[0], lay.widthReasons[0] = width, "tree prefixes"
+			}
+		}
+	}
+
+	ct.footerLayout(&lay)
+	ct.applyLockedWidths(&lay)
+	ct.hideEmptyColumns(&lay)
+	ct.fitToWidth(&lay)
+	ct.wrapValues(&lay)
+	ct.separateMultilineRows(&lay)
+	ct.separateGroups(&lay)
+	ct.annotateRows(&lay)
+	ct.flushRight(&lay)
+	ct.fitFooter(&lay)
+	ct.dittoLayout(&lay, lay.displayRows())
+
+	if ct.Trace != nil {
+		ct.traceLayout(lay)
+	}
+
+	return lay
+}
+
+// totalWidth returns the width of a full line of the table
+func (lay layout) totalWidth() int {
+
+	width := 0
+	for i, c := range lay.columns() {
+		if i > 0 {
+			width += textWidth(lay.gap)
 		}
-		// output header
-		fmt.Println(headerStr)
-		fmt.Println(headerSeparator)
+		width += lay.widths[c]
+	}
+
+	return width
+}
+
+// rowAt returns the row displayed in position i
+func (lay layout) rowAt(i int) int {
+	if lay.rowOrder == nil {
+		return i
 	}
+	return lay.rowOrder[i]
+}
 
-	for _, r := range processedRows {
-		fmt.Println(r)
+// lineAt returns the display line shown in position i
+func (lay layout) lineAt(i int) int {
+	if lay.lineOrder == nil {
+		return i
 	}
+	return lay.lineOrder[i]
+}
+
+// appendRowLines appends the display lines row r is shown on to lines - just the first for a collapsed group,
+// any wrap lines after the rest
+func (ct *Table) appendRowLines(lines []int, lay layout, r int) []int {
+
+	first, end := ct.rowLines(r)
+	if _, ok := lay.summaries[first]; ok {
+		end = first + 1
+	}
+
+	for l := first; l < end; l++ {
+		lines = append(lines, l)
+	}
+
+	return append(lines, lay.wrapLines[r]...)
+}
+
+// orderLines puts the display lines in the layout's line order, row by row in the order the rows are displayed
+func (ct *Table) orderLines(lay *layout) {
+
+	lay.lineOrder = make([]int, 0, ct.lineCount()+len(lay.wrapSources))
+	for pos := 0; pos < lay.rows; pos++ {
+		lay.lineOrder = ct.appendRowLines(lay.lineOrder, *lay, lay.rowAt(pos))
+	}
+	lay.lines = len(lay.lineOrder)
+}
+
+// columnWidths returns the display width of each column, accounting for truncation and decimal alignment,
+// and what decided each one (see trace.go)
+func (ct *Table) columnWidths(decimals []decimalLayout) ([]int, []string) {
+
+	widths := make([]int, ct.ColumnCount)
+	reasons := make([]string, ct.ColumnCount)
+
+	for i, col := range ct.Columns {
+		switch {
+		case col.Mask != "":
+			widths[i], reasons[i] = ct.displayedWidth(i), "masked"
+		case ct.abbreviations[i] != nil:
+			widths[i], reasons[i] = ct.displayedWidth(i), "abbreviated"
+		case col.truncationRequired && col.Wrap:
+			widths[i], reasons[i] = col.truncateAt, "wrapped" // longer values are wrapped to fit (see wrapValues())
+			if w := textWidth(ct.headerName(i)); w > widths[i] {
+				widths[i], reasons[i] = w, "truncated"
+			}
+		case col.truncationRequired:
+			widths[i], reasons[i] = ct.truncatedWidth(i), "truncated" // with the ... added when truncated
+		default:
+			widths[i], reasons[i] = col.maxLength, "longest value"
+			// aligning on the decimal point can make a column wider than its longest value
+			if col.Justification == "decimal" && decimals[i].width() > widths[i] {
+				widths[i], reasons[i] = decimals[i].width(), "decimal alignment"
+			}
+		}
+		if mark := ct.sortMark(i); mark != "" && widths[i] < textWidth(ct.headerName(i)+mark) {
+			widths[i], reasons[i] = textWidth(ct.headerName(i)+mark), "sort indicator"
+		}
+		if widths[i] < col.MinWidth {
+			widths[i], reasons[i] = col.MinWidth, "min width"
+		}
+		if ct.widthFloor != nil && widths[i] < ct.widthFloor[i] {
+			widths[i], reasons[i] = ct.widthFloor[i], "widest while watching"
+		}
+	}
+
+	return widths, reasons
+}
+
+// formatLine builds the output string for display line l - padding for columnar output, justification, and any truncation per column defs
+func (ct *Table) formatLine(l int, lay layout) string {
+	return ct.formatLineColumns(l, lay.columns(), lay)
+}
+
+// formatField formats field i of display line l, padded out to the column width
+func (ct *Table) formatField(l int, i int, lay layout) string {
+
+	col := ct.Columns[i]
+	fieldData := ct.fieldValue(l, i, lay)
+
+	// truncate field value? (wrapped values were wrapped to fit instead)
+	if col.truncationRequired && !col.Wrap && textWidth(fieldData) > col.truncateAt {
+		fieldData = ct.truncated(i, fieldData)
+	}
+
+	// ... and cut short to a width settled before the values were seen, or narrowed to fit (see clipped())
+	if room := lay.fieldRoom(l, i); lay.fixed && textWidth(fieldData) > room {
+		fieldData = ct.clipped(i, fieldData, room)
+	}
+
+	// a wrap line is styled like the row it belongs to
+	source := lay.sourceLine(l)
+
+	if _, ok := lay.dittos[l*ct.ColumnCount+i]; !ok {
+		fieldData = ct.typeColored(source, i, fieldData)
+	}
+	fieldData = ct.cellStyle(source, i).apply(fieldData)
+
+	if i == 0 && lay.prefixes != nil {
+		fieldData = lay.prefixes[l] + fieldData
+	}
+
+	// (tree guides alone can be wider than a narrowed first column)
+	if lay.fixed && textWidth(fieldData) > lay.widths[i] {
+		fieldData = truncateText(fieldData, lay.widths[i])
+	}
+
+	if col.PadChar != 0 {
+		return fillText(fieldData, lay.widths[i], ct.fieldJustification(source, i, lay), col.PadChar)
+	}
+
+	return padText(fieldData, lay.widths[i], ct.fieldJustification(source, i, lay))
+}
+
+// fieldValue returns field i of display line l as it's displayed before any truncation or padding
+func (ct *Table) fieldValue(l int, i int, lay layout) string {
+
+	if mark, ok := lay.dittos[l*ct.ColumnCount+i]; ok {
+		return mark
+	}
+	if value, ok := lay.wrapped[l*ct.ColumnCount+i]; ok {
+		return value
+	}
+	if lay.isWrapLine(l) {
+		return ""
+	}
+
+	// line up on the decimal point first, the aligned value is then right justified like any other
+	if ct.justification(l, i) == "decimal" {
+		return lay.decimals[i].align(ct.displayedCell(l, i))
+	}
+
+	return ct.displayedCell(l, i)
+}
+
+// formatHeader builds the column name line and the separator line that goes under it
+func (ct *Table) formatHeader(lay layout) (string, string) {
+	return ct.formatHeaderColumns(lay.columns(), lay)
+}
+
+// headerName returns the name of column i as shown in the header
+func (ct *Table) headerName(i int) string {
+
+	col := ct.Columns[i]
+	name := col.Name
+
+	// did we truncate? if so the column name may need truncating also
+	if col.truncationRequired && textWidth(name) > col.truncateAt {
+		name = ct.truncated(i, name)
+	}
+
+	return name
+}
+
+// formatHeaderField formats the name of column i and its part of the header separator, padded out to the column width
+func (ct *Table) formatHeaderField(i int, lay layout) (string, string) {
+
+	name := ct.headerName(i) + ct.sortMark(i)
+	if lay.fixed && textWidth(name) > lay.widths[i] {
+		name = ct.clipped(i, name, lay.widths[i])
+	}
+	name = ct.Columns[i].HeaderStyle.apply(name)
+
+	justification := ct.headerJustification(i)
+	if lay.flushed != nil && lay.flushed[i] {
+		justification = JustifyRight
+	}
+
+	return padText(name, lay.widths[i], justification), padText(repeatToWidth(ct.separator(ct.Theme.HeaderSeparator), lay.widths[i]), lay.widths[i], "left")
+}
+
+// Display prints the table to stdout (or wherever its output is going), see Fprint() to display it to a writer.
+func (ct *Table) Display(showHeaders bool) {
+	ct.Fprint(ct.writer(), showHeaders)
+}
+
+// display prints the table to its output
+func (ct *Table) display(showHeaders bool) {
+
+	if ct.PipedFormat != "" && !isTerminalWriter(ct.writer()) {
+		ct.writeMachineReadable(showHeaders)
+		return
+	}
+	if ct.displayEmpty() {
+		return
+	}
+
+	lay := ct.computeLayout()
+	ct.recordWarnings(lay)
+	ct.lockWidths(lay)
+
+	if ct.StackWhenWide && lay.totalWidth() > ct.availableWidth() {
+		ct.displayStacked(showHeaders, lay)
+		return
+	}
+
+	if ct.Paginate && ct.displayPaginated(showHeaders, lay) {
+		return
+	}
+
+	// the whole table is a single page of all the columns
+	ct.displayColumnPages(showHeaders, lay, [][]int{lay.columns()}, -1, false)
+}
+
+// fieldRoom returns the room there is for field i of display line l in its column, after any tree guides in front of it
+func (lay layout) fieldRoom(l int, i int) int {
+
+	if i == 0 && lay.prefixes != nil {
+		return lay.widths[0] - textWidth(lay.prefixes[l])
+	}
+
+	return lay.widths[i]
+}
+
+// rowStartLines returns the display lines that start a row (as laid out in lay)
+func (ct *Table) rowStartLines(lay layout) map[int]bool {
+
+	starts := map[int]bool{}
+	for pos := 0; pos < lay.rows; pos++ {
+		first, _ := ct.rowLines(lay.rowAt(pos))
+		starts[first] = true
+	}
+
+	return starts
+}
+
+// columns returns the indexes of the columns shown, in order
+func (lay layout) columns() []int {
+
+	cols := []int{}
+	for c := range lay.widths {
+		if lay.hidden == nil || !lay.hidden[c] {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
 }
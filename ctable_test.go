@@ -0,0 +1,324 @@
+package ctable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// testTable returns a small table with a right justified column, a truncated column, a multiline value, and a nil value
+func testTable() *Table {
+
+	ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("Size", 0), NewColumn("Notes", 12)})
+	ct.Columns[1].Justification = JustifyRight
+
+	ct.AddRow("a.txt", 12, "plain text file")
+	ct.AddRow("b, c", []string{"3", "4"}, `say "hi"`)
+	ct.AddRow("d|e", nil, "")
+
+	return &ct
+}
+
+func TestRowStorage(t *testing.T) {
+
+	ct := testTable()
+
+	if ct.RowCount != 3 || ct.lineCount() != 4 {
+		t.Fatalf("got %d rows on %d lines, want 3 rows on 4 lines", ct.RowCount, ct.lineCount())
+	}
+
+	want := []string{"a.txt|12|plain text file", "b, c|3\n4|say \"hi\"", "d|e||"}
+	for r := range want {
+		if got := strings.Join(ct.Row(r), "|"); got != want[r] {
+			t.Errorf("row %d: got %q, want %q", r, got, want[r])
+		}
+	}
+}
+
+func TestRawValues(t *testing.T) {
+
+	ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("Size", 0)})
+	ct.KeepRawValues = true
+	ct.AddRow("a.txt", 12)
+
+	if size, ok := ct.RawValue(0, 1).(int); !ok || size != 12 {
+		t.Errorf("RawValue(0, 1) = %#v, want 12", ct.RawValue(0, 1))
+	}
+	if raw := testTable().RawValue(0, 1); raw != nil {
+		t.Errorf("RawValue() without KeepRawValues = %#v, want nil", raw)
+	}
+}
+
+func TestDisplay(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		setup func(ct *Table)
+		want  string
+	}{
+		{
+			name:  "default",
+			setup: func(ct *Table) {},
+			want: `Name  Size Notes
+===== ==== ===============
+a.txt   12 plain text f...
+b, c     3 say "hi"
+         4
+d|e
+`,
+		},
+		{
+			name:  "sorted",
+			setup: func(ct *Table) { ct.SortBy("Name", true) },
+			want: `Name  Size Notes
+===== ==== ===============
+d|e
+b, c     3 say "hi"
+         4
+a.txt   12 plain text f...
+`,
+		},
+		{
+			name: "wrapped",
+			setup: func(ct *Table) {
+				ct.Columns[2].Wrap = true
+				ct.Columns[2].WrapMarker = "↳ "
+			},
+			want: `Name  Size Notes
+===== ==== ============
+a.txt   12 plain text
+           ↳ file
+b, c     3 say "hi"
+         4
+d|e
+`,
+		},
+		{
+			name: "fitted",
+			setup: func(ct *Table) {
+				ct.Columns[2].setTruncateAt(0)
+				ct.FitToWidth = true
+				ct.MaxWidth = 20
+			},
+			want: `Name Size Notes
+==== ==== ==========
+a...   12 plain t...
+b, c    3 say "hi"
+        4
+d|e
+`,
+		},
+		{
+			name:  "title",
+			setup: func(ct *Table) { ct.Title = "Files" },
+			want: `Files
+Name  Size Notes
+===== ==== ===============
+a.txt   12 plain text f...
+b, c     3 say "hi"
+         4
+d|e
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := testTable()
+			tt.setup(ct)
+			got, err := ct.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+
+	var buf bytes.Buffer
+	if err := testTable().WriteCSV(&buf, CSVOptions{ShowHeaders: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Name,Size,Notes
+a.txt,12,plain text file
+"b, c","3
+4","say ""hi"""
+d|e,,
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteCSVSorted(t *testing.T) {
+
+	ct := testTable()
+	ct.SortBy("Name", true)
+
+	var buf bytes.Buffer
+	if err := ct.WriteCSV(&buf, CSVOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `d|e,,
+"b, c","3
+4","say ""hi"""
+a.txt,12,plain text file
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+
+	var buf bytes.Buffer
+	if err := testTable().RenderJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[
+  {"Name": "a.txt", "Size": "12", "Notes": "plain text file"},
+  {"Name": "b, c", "Size": ["3","4"], "Notes": "say \"hi\""},
+  {"Name": "d|e", "Size": "", "Notes": ""}
+]
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+
+	ct := testTable()
+
+	var buf bytes.Buffer
+	if err := ct.RenderMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `| Name  | Size   | Notes           |
+| :---- | -----: | :-------------- |
+| a.txt |     12 | plain text file |
+| b, c  | 3<br>4 | say "hi"        |
+| d\|e  |        |                 |
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	read, err := FromMarkdown(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.RowCount != ct.RowCount {
+		t.Fatalf("read %d rows, want %d", read.RowCount, ct.RowCount)
+	}
+	for r := 0; r < ct.RowCount; r++ {
+		if got, want := strings.Join(read.Row(r), "|"), strings.Join(ct.Row(r), "|"); got != want {
+			t.Errorf("row %d: got %q, want %q", r, got, want)
+		}
+	}
+	if j := read.Columns[1].Justification; j != JustifyRight {
+		t.Errorf("Size justification: got %q, want %q", j, JustifyRight)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+
+	var buf bytes.Buffer
+	if err := testTable().RenderHTML(&buf, HTMLOptions{ShowHeaders: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<table>
+<thead>
+<tr><th scope="col">Name</th><th scope="col" style="text-align: right">Size</th><th scope="col">Notes</th></tr>
+</thead>
+<tbody>
+<tr><td>a.txt</td><td style="text-align: right">12</td><td>plain text file</td></tr>
+<tr><td>b, c</td><td style="text-align: right">3<br>4</td><td>say &#34;hi&#34;</td></tr>
+<tr><td>d|e</td><td style="text-align: right"></td><td></td></tr>
+</tbody>
+</table>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestColumnarStorage(t *testing.T) {
+
+	rows := testTable()
+	want := rows.String()
+
+	// switched over with rows already added, and before any are
+	moved := testTable()
+	moved.UseColumnarStorage()
+
+	columnar := NewTable([]Column{NewColumn("Name", 0), NewColumn("Size", 0), NewColumn("Notes", 12)})
+	columnar.UseColumnarStorage()
+	columnar.Columns[1].Justification = JustifyRight
+	for r := 0; r < rows.RowCount; r++ {
+		fields := []interface{}{}
+		for _, value := range rows.Row(r) {
+			if strings.Contains(value, "\n") {
+				fields = append(fields, strings.Split(value, "\n"))
+			} else {
+				fields = append(fields, value)
+			}
+		}
+		columnar.AddRow(fields...)
+	}
+
+	for name, ct := range map[string]*Table{"moved": moved, "added": &columnar} {
+		if got := ct.String(); got != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
+
+func TestRenderGoLiteral(t *testing.T) {
+
+	ct := testTable()
+	var out strings.Builder
+	ct.output = &out
+
+	want := "`Name  Size Notes\n===== ==== ===============\na.txt   12 plain text f...\nb, c     3 say \"hi\"\n         4\nd|e\n`"
+	if got := ct.RenderGoLiteral(true); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// the table's own output is left alone
+	ct.Display(false)
+	if out.Len() == 0 {
+		t.Error("Display() after RenderGoLiteral() didn't write to the table's output")
+	}
+}
+
+func TestRows(t *testing.T) {
+
+	want := [][]interface{}{
+		{"a.txt", "12", "plain text file"},
+		{"b, c", "3", `say "hi"`},
+		{"", "4", ""},
+		{"d|e", "", ""},
+	}
+
+	got := testTable().Rows()
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+	for l := range want {
+		for c := range want[l] {
+			if got[l][c] != want[l][c] {
+				t.Errorf("line %d field %d: got %q, want %q", l, c, got[l][c], want[l][c])
+			}
+		}
+	}
+}
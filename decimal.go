@@ -0,0 +1,58 @@
+package ctable
+
+import "strings"
+
+/*
+Decimal point alignment.
+
+Columns with Justification set to "decimal" line their values up on the decimal point:
+integer parts are right justified and fractional parts left justified, so mixed precision values like
+1.5, 100, and 0.125 still read as a column of numbers. Values without a point are treated as all integer part.
+*/
+
+// decimalLayout holds the widest integer part and widest fractional part (including the point) of a column's values
+type decimalLayout struct {
+	intWidth  int
+	fracWidth int
+}
+
+func (dl decimalLayout) width() int {
+	return dl.intWidth + dl.fracWidth
+}
+
+// align pads the integer and fractional parts of value out to the layout's widths
+func (dl decimalLayout) align(value string) string {
+	intPart, fracPart := splitDecimal(value)
+	return padText(intPart, dl.intWidth, "right") + padText(fracPart, dl.fracWidth, "left")
+}
+
+// splitDecimal splits value at its decimal point, the point stays with the fractional part
+func splitDecimal(value string) (string, string) {
+	if i := strings.LastIndex(value, "."); i >= 0 {
+		return value[:i], value[i:]
+	}
+	return value, ""
+}
+
+// decimalLayouts measures the values of every decimal justified column, other columns get a zero layout
+func (ct *Table) decimalLayouts() []decimalLayout {
+
+	layouts := make([]decimalLayout, ct.ColumnCount)
+
+	for i, col := range ct.Columns {
+		if col.Justification != "decimal" {
+			continue
+		}
+		ct.scanColumn(i, func(value string) {
+			intPart, fracPart := splitDecimal(value)
+			if textWidth(intPart) > layouts[i].intWidth {
+				layouts[i].intWidth = textWidth(intPart)
+			}
+			if textWidth(fracPart) > layouts[i].fracWidth {
+				layouts[i].fracWidth = textWidth(fracPart)
+			}
+		})
+	}
+
+	return layouts
+}
@@ -0,0 +1,59 @@
+package ctable
+
+/*
+Package level defaults.
+
+An application that wants the same look everywhere can set it once at startup with SetDefaults() instead of on every
+table, e.g.
+
+	sep := "|"
+	ctable.SetDefaults(ctable.Defaults{Separator: &sep, EmptyValue: "-"})
+
+The defaults apply to tables and columns created afterwards (NewTable(), NewColumn()), settings made on a table after
+it's created still win, as do the CTABLE_* environment variables (see env.go). SetDefaults() isn't safe to call while
+other goroutines are creating tables, it's meant for program startup.
+*/
+
+type Defaults struct {
+	Theme         *Theme        // theme for new tables, nil for DefaultTheme()
+	Separator     *string       // column separator (replacing the theme's), nil to leave the theme's separator as is
+	Justification Justification // justification for new columns, "" for JustifyLeft
+	EmptyValue    string        // placeholder shown for nil fields
+}
+
+var defaults Defaults
+
+// SetDefaults replaces the package level defaults used by NewTable() and NewColumn(), SetDefaults(Defaults{}) puts the
+// original defaults back.
+func SetDefaults(opts Defaults) {
+	defaults = opts
+}
+
+// GetDefaults returns the current package level defaults.
+func GetDefaults() Defaults {
+	return defaults
+}
+
+// defaultTheme returns the theme new tables start with
+func defaultTheme() Theme {
+
+	th := DefaultTheme()
+	if defaults.Theme != nil {
+		th = *defaults.Theme
+	}
+	if defaults.Separator != nil {
+		th.ColumnSeparator = *defaults.Separator
+	}
+
+	return th
+}
+
+// defaultJustification returns the justification new columns start with
+func defaultJustification() Justification {
+
+	if defaults.Justification == "" {
+		return "left"
+	}
+
+	return defaults.Justification
+}
@@ -0,0 +1,79 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Ditto marks.
+
+In a sorted or grouped listing the same value often runs down a column row after row. Setting Ditto on a column
+replaces a value that's the same as the one in the row displayed above it with DittoMark, or leaves it blank when
+DittoMark is "", so the eye only lands on the values that change:
+
+	Region     Host
+	=========  ======
+	eu-west-1  web-1
+	"          web-2
+	us-east-1  web-3
+
+The comparison is in display order (after any tree ordering, and with the viewer's sorting and filtering),
+and a multiline value is only replaced when all of its lines are the same.
+*/
+
+// dittoLayout works out which fields are shown as ditto marks when the rows are displayed in the order given,
+// keyed by display line * ColumnCount + column - nothing is set when no column has Ditto (or Merge) set
+func (ct *Table) dittoLayout(lay *layout, rows []int) {
+
+	lay.dittos = nil
+
+	for c, col := range ct.Columns {
+		if !col.Ditto && !col.Merge {
+			continue
+		}
+		mark := col.DittoMark
+		if col.Merge {
+			mark = ""
+		}
+
+		previous, havePrevious := "", false
+		for _, r := range rows {
+			first, end := ct.rowLines(r)
+
+			// collapsed groups don't show the value, so there's nothing to repeat either side of them
+			if _, ok := lay.summaries[first]; ok {
+				havePrevious = false
+				continue
+			}
+
+			// (rows run to as many lines as their tallest field, so blank lines at the end don't count)
+			value := ""
+			for l := first; l < end; l++ {
+				value += ct.cell(l, c) + "\n"
+			}
+			value = strings.TrimRight(value, "\n")
+
+			if havePrevious && value == previous && ct.cell(first, c) != "" {
+				if lay.dittos == nil {
+					lay.dittos = map[int]string{}
+				}
+				lay.dittos[first*ct.ColumnCount+c] = mark
+				for _, l := range ct.appendRowLines(nil, *lay, r)[1:] {
+					lay.dittos[l*ct.ColumnCount+c] = ""
+				}
+			}
+			previous, havePrevious = value, true
+		}
+	}
+}
+
+// displayRows returns the rows in display order
+func (lay layout) displayRows() []int {
+
+	rows := make([]int, lay.rows)
+	for pos := range rows {
+		rows[pos] = lay.rowAt(pos)
+	}
+
+	return rows
+}
@@ -0,0 +1,31 @@
+package ctable
+
+/*
+Empty tables.
+
+A table with no rows displays as just its header, which says little more than nothing at all. With EmptyMessage set,
+Display() (and DisplayPaged()) print the message instead, so every command shows an empty result the same way:
+
+	ct.EmptyMessage = "No resources found."
+	ct.Display(true)   // No resources found.
+
+IsEmpty() tells whether a table has any rows, for callers that want to do something else with an empty result.
+Machine readable output (PipedFormat) is written as usual - an empty result for scripts is no rows, not a message.
+*/
+
+// IsEmpty returns true if the table has no rows.
+func (ct *Table) IsEmpty() bool {
+	return ct.RowCount == 0
+}
+
+// displayEmpty prints EmptyMessage in place of an empty table, false (with nothing printed) if the table isn't empty or there's no message
+func (ct *Table) displayEmpty() bool {
+
+	if !ct.IsEmpty() || ct.EmptyMessage == "" {
+		return false
+	}
+
+	ct.printLine(ct.EmptyMessage)
+
+	return true
+}
@@ -0,0 +1,129 @@
+package ctable
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Output encodings.
+
+Everything printed is UTF-8, which is what terminals almost everywhere expect. For those that don't - an old Windows
+console on code page 437, a serial terminal, a legacy system reading Windows-1252 - SetOutputEncoding() has printed
+lines transcoded on the way out. Characters the encoding doesn't have are swapped for the nearest it does: rounded
+and heavy box drawing for light, then box drawing (frames, separators, tree guides), arrows, and the like for ASCII,
+and anything else for "?" - "??" for a wide character, nothing for a zero width one. Stand-ins take up as many columns
+as the characters they replace, so columns stay lined up.
+
+	if err := ct.SetOutputEncoding("cp437"); err != nil { ... }
+
+Encodings: "utf-8" (the default), "cp437", "windows-1252", "iso-8859-1" (Latin-1), and "ascii".
+*/
+
+// charmap is a single byte encoding - ASCII plus what the bytes 0x80-0xff stand for (nil for ASCII alone)
+type charmap struct {
+	high  []rune
+	bytes map[rune]byte // reverse of high, built when first needed
+}
+
+var charmaps = map[string]*charmap{
+	"cp437": {high: []rune("ÇüéâäàåçêëèïîìÄÅÉæÆôöòûùÿÖÜ¢£¥₧ƒáíóúñÑªº¿⌐¬½¼¡«»░▒▓│┤╡╢╖╕╣║╗╝╜╛┐" +
+		"└┴┬├─┼╞╟╚╔╩╦╠═╬╧╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀αßΓπΣσµτΦΘΩδ∞φε∩≡±≥≤⌠⌡÷≈°∙·√ⁿ²■\u00a0")},
+	"windows-1252": {high: []rune("€\u0081‚ƒ„…†‡ˆ‰Š‹Œ\u008dŽ\u008f\u0090‘’“”•–—˜™š›œ\u009džŸ" + latin1High)},
+	"iso-8859-1": {high: []rune("\u0080\u0081\u0082\u0083\u0084\u0085\u0086\u0087\u0088\u0089\u008a\u008b\u008c\u008d\u008e\u008f" +
+		"\u0090\u0091\u0092\u0093\u0094\u0095\u0096\u0097\u0098\u0099\u009a\u009b\u009c\u009d\u009e\u009f" + latin1High)},
+	"ascii": {},
+}
+
+// the characters 0xa0-0xff of Latin-1 (and Windows-1252)
+const latin1High = " ¡¢£¤¥¦§¨©ª«¬­®¯°±²³´µ¶·¸¹º»¼½¾¿ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏÐÑÒÓÔÕÖ×ØÙÚÛÜÝÞßàáâãäåæçèéêëìíîïðñòóôõö÷øùúûüýþÿ"
+
+// other names encodings go by
+var encodingAliases = map[string]string{
+	"ibm437": "cp437", "437": "cp437",
+	"cp1252": "windows-1252", "1252": "windows-1252",
+	"latin1": "iso-8859-1", "latin-1": "iso-8859-1",
+	"us-ascii": "ascii",
+}
+
+// stand-ins for characters an encoding doesn't have, tried in turn - first the nearest box drawing character, then ASCII
+var (
+	boxStandIns = map[rune]rune{
+		'╭': '┌', '╮': '┐', '╰': '└', '╯': '┘',
+		'━': '─', '┃': '│', '┏': '┌', '┓': '┐', '┗': '└', '┛': '┘',
+		'┣': '├', '┫': '┤', '┳': '┬', '┻': '┴', '╋': '┼',
+		'┝': '├', '┥': '┤', '┿': '┼',
+	}
+	asciiStandIns = map[rune]rune{
+		'─': '-', '│': '|', '═': '=', '║': '|',
+		'┌': '+', '┐': '+', '└': '+', '┘': '+', '├': '+', '┤': '+', '┬': '+', '┴': '+', '┼': '+',
+		'╔': '+', '╗': '+', '╚': '+', '╝': '+', '╠': '+', '╣': '+', '╦': '+', '╩': '+', '╬': '+',
+		'╞': '+', '╡': '+', '╪': '+',
+		'▶': '>', '↳': '>', '…': '.', '·': '.', '•': '*',
+		'▁': '.', '▂': ':', '▃': '-', '▄': '=', '▅': '+', '▆': '*', '▇': '#', '█': '@',
+		'‘': '\'', '’': '\'', '“': '"', '”': '"', '–': '-', '—': '-',
+	}
+)
+
+// SetOutputEncoding sets the encoding lines are printed in (see encoding.go), "" or "utf-8" for UTF-8.
+func (ct *Table) SetOutputEncoding(name string) error {
+
+	name = strings.ToLower(name)
+	if alias, ok := encodingAliases[name]; ok {
+		name = alias
+	}
+
+	switch cm, ok := charmaps[name]; {
+	case name == "" || name == "utf-8" || name == "utf8":
+		ct.encoding = nil
+	case ok:
+		ct.encoding = cm
+	default:
+		return fmt.Errorf("CONSOLETABLE: unknown output encoding %q", name)
+	}
+
+	return nil
+}
+
+// encode returns s in the charmap's encoding, with stand-ins for the characters it doesn't have
+func (cm *charmap) encode(s string) string {
+
+	if cm.bytes == nil {
+		cm.bytes = map[rune]byte{}
+		for i, r := range cm.high {
+			cm.bytes[r] = byte(0x80 + i)
+		}
+	}
+
+	encoded := func(r rune) (byte, bool) {
+		if r < 0x80 {
+			return byte(r), true
+		}
+		b, ok := cm.bytes[r]
+		return b, ok
+	}
+
+	var sb strings.Builder
+	for _, r := range s {
+		b, ok := encoded(r)
+		if !ok {
+			if standIn, found := boxStandIns[r]; found {
+				r = standIn
+				b, ok = encoded(r)
+			}
+		}
+		if !ok {
+			if standIn, found := asciiStandIns[r]; found {
+				b, ok = byte(standIn), true
+			}
+		}
+		if !ok {
+			// as many as the character took up on screen
+			sb.WriteString(strings.Repeat("?", widthFunc(r)))
+			continue
+		}
+		sb.WriteByte(b)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,23 @@
+package ctable
+
+import (
+	"testing"
+)
+
+func TestEncodeStandIns(t *testing.T) {
+
+	tests := []struct {
+		in, want string
+	}{
+		{"╭─┬─╮", "+-+-+"},
+		{"│ café │", "| caf\xe9 |"},
+		{"日本 │", "???? |"},
+		{"e\u0301", "e"}, // a combining accent takes no room
+	}
+
+	for _, tt := range tests {
+		if got := charmaps["iso-8859-1"].encode(tt.in); got != tt.want {
+			t.Errorf("encode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
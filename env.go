@@ -0,0 +1,74 @@
+package ctable
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+/*
+Environment variable overrides.
+
+Operators can adjust the output of every tool built on ctable without each tool plumbing flags through.
+The variables are read by NewTable() and replace the defaults, so settings a tool makes in code afterwards still win.
+
+	CTABLE_STYLE      name of a built-in theme (see ThemeByName()) or path to a theme file (see LoadTheme())
+	CTABLE_MAXWIDTH   width the output should fit in (instead of the terminal width) when laying out wide tables
+	CTABLE_ASCII      any true value (1, true, yes...) - use ASCII instead of box drawing characters for frames, tree guides etc.
+	CTABLE_TRACE      any true value - trace layout decisions to stderr (see trace.go)
+
+Values that can't be used (unknown theme, unreadable file, not a number) are ignored.
+*/
+
+// applyEnvironment sets the table's defaults from the CTABLE_* environment variables
+func (ct *Table) applyEnvironment() {
+
+	if style := os.Getenv("CTABLE_STYLE"); style != "" {
+		if th, ok := ThemeByName(style); ok {
+			ct.Theme = th
+		} else if th, err := LoadTheme(style); err == nil {
+			ct.Theme = th
+		}
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("CTABLE_MAXWIDTH")); err == nil && width > 0 {
+		ct.MaxWidth = width
+	}
+
+	if ascii, err := strconv.ParseBool(os.Getenv("CTABLE_ASCII")); err == nil {
+		ct.ASCII = ascii
+	} else if os.Getenv("CTABLE_ASCII") == "yes" {
+		ct.ASCII = true
+	}
+
+	if trace, err := strconv.ParseBool(os.Getenv("CTABLE_TRACE")); (err == nil && trace) || os.Getenv("CTABLE_TRACE") == "yes" {
+		ct.Trace = os.Stderr
+	}
+}
+
+// box drawing characters themes use, and their ASCII stand-ins
+var asciiReplacer = strings.NewReplacer(
+	"│", "|", "┃", "|", "║", "|",
+	"─", "-", "━", "-", "═", "=",
+	"┼", "+", "╋", "+", "╬", "+",
+)
+
+// separator returns a theme separator as it's drawn, with ASCII stand-ins for box drawing characters when ASCII is set
+func (ct *Table) separator(s string) string {
+
+	if !ct.ASCII {
+		return s
+	}
+
+	return asciiReplacer.Replace(s)
+}
+
+// availableWidth returns the width table lines have to fit in, MaxWidth if set, otherwise the terminal width (less any Indent(), LinePrefix, and LineSuffix)
+func (ct *Table) availableWidth() int {
+
+	if ct.MaxWidth > 0 {
+		return ct.MaxWidth - ct.decorationWidth()
+	}
+
+	return terminalWidth() - ct.decorationWidth()
+}
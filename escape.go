@@ -0,0 +1,70 @@
+package ctable
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"strings"
+)
+
+/*
+Escaping.
+
+Every text format the table is written in escapes cell values its own way - TSV writes tabs as \t, Markdown writes
+pipes as \|, HTML and SVG write < as &lt;, and so on. SetEscaper() swaps out the escaping for one format, for data
+the defaults don't suit, e.g. keeping HTML markup in cells that's meant to be rendered:
+
+	ct.SetEscaper("html", func(s string) string { return s })
+
+Formats: "tsv", "plain", "csv" (applied before the quoting, nothing by default), "markdown", "html", and "svg".
+Escapers get the cell text with any ANSI styling already stripped, and column names go through them too.
+*/
+
+// default escaping per format
+var defaultEscapers = map[string]func(string) string{
+	"tsv":      tsvEscaper.Replace,
+	"plain":    plainField,
+	"csv":      func(s string) string { return s },
+	"markdown": markdownEscaper.Replace,
+	"html":     html.EscapeString,
+	"svg":      xmlEscape,
+}
+
+// pipes would end the cell, and a line break the row
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// SetEscaper sets how text is escaped for format (see escape.go), nil goes back to the default.
+func (ct *Table) SetEscaper(format string, fn func(string) string) {
+
+	format = strings.ToLower(format)
+	if _, ok := defaultEscapers[format]; !ok {
+		ct.fatal("SetEscaper() format " + format + " doesn't exist.")
+		return
+	}
+
+	if fn == nil {
+		delete(ct.escapers, format)
+		return
+	}
+	if ct.escapers == nil {
+		ct.escapers = map[string]func(string) string{}
+	}
+	ct.escapers[format] = fn
+}
+
+// escape escapes s for format, with the table's own escaper if it has one
+func (ct *Table) escape(format string, s string) string {
+
+	if fn, ok := ct.escapers[format]; ok {
+		return fn(s)
+	}
+
+	return defaultEscapers[format](s)
+}
+
+// xmlEscape escapes s for XML text
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,104 @@
+package ctable
+
+import (
+	"sort"
+)
+
+/*
+Fitting the terminal.
+
+A table wider than the terminal wraps each line onto the next, and the columns are lost. With FitToWidth set, columns
+are narrowed until the table fits MaxWidth (or the terminal width), and values too wide for their narrowed column are cut
+short with "..." (or wrapped, in a column with Wrap set):
+
+	ct.FitToWidth = true
+	ct.Columns[0].FitPriority = 1   // the name column is the last to give up room
+
+Columns give up room in order of FitPriority, lowest first - the columns with the lowest priority are narrowed (in
+proportion to their widths) as far as they go, then those with the next priority, and so on. Columns aren't narrowed
+below their MinWidth, or below fitMinWidth characters when they don't have one, so a table with many columns on a
+narrow terminal can still come out too wide (see StackWhenWide for that).
+*/
+
+// narrowest a column is made to fit the table, unless its MinWidth says otherwise
+const fitMinWidth = 4
+
+// fitToWidth narrows columns so the table fits the width available
+func (ct *Table) fitToWidth(lay *layout) {
+
+	if !ct.FitToWidth {
+		return
+	}
+
+	excess := lay.totalWidth() - ct.availableWidth()
+	if excess <= 0 {
+		return
+	}
+
+	// the columns shown, grouped by priority, lowest first
+	byPriority := map[int][]int{}
+	priorities := []int{}
+	for _, c := range lay.columns() {
+		p := ct.Columns[c].FitPriority
+		if _, ok := byPriority[p]; !ok {
+			priorities = append(priorities, p)
+		}
+		byPriority[p] = append(byPriority[p], c)
+	}
+	sort.Ints(priorities)
+
+	for _, p := range priorities {
+		excess -= ct.narrowColumns(lay, byPriority[p], excess)
+		if excess <= 0 {
+			break
+		}
+	}
+
+	lay.fixed = true
+}
+
+// narrowColumns takes up to excess characters off the widths of cols, in proportion to the room each has to give,
+// and returns how many it took off
+func (ct *Table) narrowColumns(lay *layout, cols []int, excess int) int {
+
+	spare := make([]int, len(cols))
+	totalSpare := 0
+	for i, c := range cols {
+		min := ct.Columns[c].MinWidth
+		if min <= 0 {
+			min = fitMinWidth
+		}
+		if spare[i] = lay.widths[c] - min; spare[i] < 0 {
+			spare[i] = 0
+		}
+		totalSpare += spare[i]
+	}
+	if totalSpare == 0 {
+		return 0
+	}
+	if excess > totalSpare {
+		excess = totalSpare
+	}
+
+	// each column's share rounded down, then what's left of the excess a character at a time to the columns with room left
+	taken := 0
+	for i, c := range cols {
+		share := spare[i] * excess / totalSpare
+		lay.widths[c] -= share
+		spare[i] -= share
+		taken += share
+		if share > 0 {
+			lay.widthReasons[c] = "fitted to width"
+		}
+	}
+	for i := 0; taken < excess; i = (i + 1) % len(cols) {
+		if spare[i] > 0 {
+			lay.widths[cols[i]]--
+			lay.widthReasons[cols[i]] = "fitted to width"
+			spare[i]--
+			taken++
+		}
+	}
+
+	return taken
+}
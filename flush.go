@@ -0,0 +1,54 @@
+package ctable
+
+/*
+Flush right.
+
+Status displays often want the value hard against the right edge of the screen, with the name at the left:
+
+	Database.......................................       up
+	Cache..........................................       up
+	Queue.......................................... degraded
+
+With FlushRight set the table is stretched to the full width it has (MaxWidth, or the terminal's) by widening the column
+before the last, so the last column ends at the right edge, and the last column's values (and name) are right justified
+so each of them ends there too, whatever the column's Justification. The widened column is padded out as usual - with
+spaces, or with its PadChar for leader dots as above:
+
+	ct := ctable.NewTable([]ctable.Column{ctable.NewColumn("Service", 0), ctable.NewColumn("Status", 0)})
+	ct.Columns[0].PadChar = '.'
+	ct.FlushRight = true
+
+Tables already as wide as the width they have (or with just the one column showing) are left as they are.
+*/
+
+// flushRight widens the column before the last so the table takes up the full width available, and right justifies the last
+func (ct *Table) flushRight(lay *layout) {
+
+	if !ct.FlushRight {
+		return
+	}
+
+	cols := lay.columns()
+	if len(cols) < 2 {
+		return
+	}
+
+	if extra := ct.availableWidth() - lay.totalWidth(); extra > 0 {
+		lay.widths[cols[len(cols)-2]] += extra
+		lay.widthReasons[cols[len(cols)-2]] = "flush right"
+	}
+
+	lay.flushed = make([]bool, ct.ColumnCount)
+	lay.flushed[cols[len(cols)-1]] = true
+}
+
+// fieldJustification returns the justification of field c of display line l as laid out in lay
+func (ct *Table) fieldJustification(l int, c int, lay layout) Justification {
+
+	j := ct.justification(l, c)
+	if lay.flushed != nil && lay.flushed[c] && j != JustifyDecimal {
+		return JustifyRight
+	}
+
+	return j
+}
@@ -0,0 +1,31 @@
+package ctable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlushRight(t *testing.T) {
+
+	ct := NewTable([]Column{NewColumn("Service", 0), NewColumn("Status", 0)})
+	ct.Columns[0].PadChar = '.'
+	ct.AddRow("Database", "up")
+	ct.AddRow("Queue", "degraded")
+	ct.FlushRight = true
+	ct.MaxWidth = 30
+
+	var trace strings.Builder
+	ct.Trace = &trace
+
+	want := `Service                 Status
+===================== ========
+Database.............       up
+Queue................ degraded
+`
+	if got := ct.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if !strings.Contains(trace.String(), `"Service"  longest 8, allotted 21 - flush right`) {
+		t.Errorf("trace doesn't give flush right as the reason for the width of Service:\n%s", trace.String())
+	}
+}
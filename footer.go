@@ -0,0 +1,225 @@
+package ctable
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Summary footer.
+
+Setting Footer adds a summary of each numeric column's values under the table (columns with any non numeric values
+are left blank):
+
+	"range"      three lines, the smallest value, the median, and the largest (labeled in the first column when
+	             it isn't numeric itself)
+	"sparkline"  one line, a histogram of the values as wide as the column, e.g. ▁▃█▅▂
+
+	Host   CPU  Mem
+	====== ==== ===
+	web-1  0.5  512
+	web-2  2.25 384
+	web-3  1    768
+	====== ==== ===
+	min    0.5  384
+	median 1    512
+	max    2.25 768
+*/
+
+// histogram bar heights, lowest to highest
+var (
+	sparkBars      = []rune("▁▂▃▄▅▆▇█")
+	asciiSparkBars = []rune(".:-=+*#@")
+)
+
+// footerLayout works out the footer lines, widening columns to fit them - nothing is set when there's no Footer.
+// It comes before the widths are locked or fitted, fitFooter() then makes the footer fit the widths they end up with.
+func (ct *Table) footerLayout(lay *layout) {
+
+	lines := 0
+	switch strings.ToLower(ct.Footer) {
+	case "range":
+		lines = 3
+	case "sparkline":
+		lines = 1
+	}
+	if lines == 0 || ct.RowCount == 0 {
+		return
+	}
+
+	lay.footer = make([][]string, lines)
+	for i := range lay.footer {
+		lay.footer[i] = make([]string, ct.ColumnCount)
+	}
+
+	for c := range ct.Columns {
+		numbers, ok := ct.columnNumbers(c)
+		if !ok {
+			if c == 0 && lines == 3 {
+				lay.footer[0][0], lay.footer[1][0], lay.footer[2][0] = "min", "median", "max"
+			}
+			continue
+		}
+
+		if lines == 1 {
+			continue // drawn as wide as the column by fitFooter()
+		}
+
+		sort.Float64s(numbers)
+		median := numbers[len(numbers)/2]
+		if len(numbers)%2 == 0 {
+			median = (numbers[len(numbers)/2-1] + median) / 2
+		}
+		precision := ct.Columns[c].Precision
+		lay.footer[0][c] = strconv.FormatFloat(numbers[0], 'f', precision, 64)
+		lay.footer[1][c] = strconv.FormatFloat(median, 'f', precision, 64)
+		lay.footer[2][c] = strconv.FormatFloat(numbers[len(numbers)-1], 'f', precision, 64)
+	}
+
+	for _, line := range lay.footer {
+		for c, value := range line {
+			if w := textWidth(value); w > lay.widths[c] {
+				lay.widths[c], lay.widthReasons[c] = w, "footer"
+			}
+		}
+	}
+}
+
+// fitFooter draws the sparklines as wide as their columns and cuts short range values wider than theirs, for when the
+// widths were locked, fitted, or flushed right after footerLayout()
+func (ct *Table) fitFooter(lay *layout) {
+
+	if lay.footer == nil {
+		return
+	}
+
+	for c := range ct.Columns {
+		if len(lay.footer) == 1 {
+			if numbers, ok := ct.columnNumbers(c); ok {
+				lay.footer[0][c] = ct.sparkline(numbers, lay.widths[c])
+			}
+			continue
+		}
+		for _, line := range lay.footer {
+			if textWidth(line[c]) > lay.widths[c] {
+				line[c] = ct.clipped(c, line[c], lay.widths[c])
+			}
+		}
+	}
+}
+
+// columnNumbers returns the values of column c as numbers, ok is false if any of them isn't one (or there are none,
+// or the column's masked)
+func (ct *Table) columnNumbers(c int) (numbers []float64, ok bool) {
+
+	if ct.Columns[c].Mask != "" {
+		return nil, false
+	}
+
+	for r := 0; r < ct.RowCount; r++ {
+		value := ct.Row(r)[c]
+		if strings.TrimSpace(value) == "" || value == ct.EmptyValue {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(stripANSI(value)), 64)
+		if err != nil {
+			return nil, false
+		}
+		numbers = append(numbers, n)
+	}
+
+	return numbers, len(numbers) > 0
+}
+
+// sparkline returns a histogram of numbers width characters wide, each character a bucket of the range of values
+func (ct *Table) sparkline(numbers []float64, width int) string {
+
+	bars := sparkBars
+	if ct.ASCII {
+		bars = asciiSparkBars
+	}
+	if width < 1 {
+		width = 1
+	}
+
+	low, high := numbers[0], numbers[0]
+	for _, n := range numbers {
+		if n < low {
+			low = n
+		}
+		if n > high {
+			high = n
+		}
+	}
+
+	counts := make([]int, width)
+	most := 0
+	for _, n := range numbers {
+		bucket := 0
+		if high > low {
+			bucket = int((n - low) / (high - low) * float64(width))
+		}
+		if bucket >= width {
+			bucket = width - 1
+		}
+		counts[bucket]++
+		if counts[bucket] > most {
+			most = counts[bucket]
+		}
+	}
+
+	var sb strings.Builder
+	for _, count := range counts {
+		if count == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		sb.WriteRune(bars[(count*len(bars)-1)/most])
+	}
+
+	return sb.String()
+}
+
+// printFooter prints the footer lines for a page of columns under a rule, if there's a footer
+func (ct *Table) printFooter(lay layout, cols []int, f *framer) {
+
+	if lay.footer == nil {
+		return
+	}
+
+	if f != nil {
+		ct.printLine(f.line(f.frame.rule, ""))
+	} else if ct.Theme.HeaderSeparator != "" {
+		ct.printLine(ct.formatRuleColumns(ct.separator(ct.Theme.HeaderSeparator), cols, lay))
+	}
+
+	for _, fields := range lay.footer {
+		line := ""
+		for i, c := range cols {
+			if i > 0 {
+				line += lay.gap
+			}
+			// justified like the column, decimal points don't line up across summaries of different kinds though
+			justification := ct.Columns[c].Justification
+			if justification == "decimal" {
+				justification = "right"
+			}
+			line += padText(fields[c], lay.widths[c], justification)
+		}
+		ct.printLine(framed(f, line))
+	}
+}
+
+// footerHeight returns the number of lines the footer takes up, its rule included
+func (ct *Table) footerHeight(lay layout) int {
+
+	if lay.footer == nil {
+		return 0
+	}
+	if ct.frameWidth() > 0 || ct.Theme.HeaderSeparator != "" {
+		return len(lay.footer) + 1
+	}
+
+	return len(lay.footer)
+}
@@ -0,0 +1,50 @@
+package ctable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFooterFitted(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		setup func(ct *Table)
+		width int
+	}{
+		{name: "fitted", setup: func(ct *Table) {
+			ct.FitToWidth = true
+			ct.MaxWidth = 9
+		}, width: 9},
+		{name: "locked", setup: func(ct *Table) { ct.SetColumnWidths(4, 0) }, width: 9},
+		{name: "flush right", setup: func(ct *Table) {
+			ct.FlushRight = true
+			ct.MaxWidth = 12
+		}, width: 12},
+		{name: "sparkline", setup: func(ct *Table) {
+			ct.Footer = "sparkline"
+			ct.FlushRight = true
+			ct.MaxWidth = 12
+		}, width: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := NewTable([]Column{NewColumn("Host", 0), NewColumn("Load", 0)})
+			ct.AddRow("a", 0.5)
+			ct.AddRow("b", 2.25)
+			ct.AddRow("c", 1)
+			ct.Footer = "range"
+			tt.setup(&ct)
+
+			// the footer's labels ("median") are wider than the first column, they have to fit it rather than widen it
+			lines := strings.Split(strings.TrimSuffix(ct.String(), "\n"), "\n")
+			for _, line := range lines {
+				if textWidth(line) > tt.width {
+					t.Errorf("footer widened the table past %d:\n%s", tt.width, strings.Join(lines, "\n"))
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Frames.
+
+Setting the theme's Frame draws a box around the table with box drawing (or ASCII) characters. Column separators
+(Theme.ColumnSeparator) meet the top and bottom lines and the rule under the header with the matching joins, e.g.
+with Frame "light", ColumnSeparator "│", and a Title:
+
+	┌─ Nodes ──┬────────┐
+	│ Name     │ Status │
+	├──────────┼────────┤
+	│ node-1   │ Ready  │
+	└──────────┴────────┘
+
+The rule under the header is drawn when the theme has a HeaderSeparator (whatever it is, the frame's characters are used).
+Frames: "light", "rounded" (light with rounded corners), "heavy", "double", "ascii", "markdown" (pipes and dashes, for
+an OpenFrame - a frame of just the sides, without the lines across the top and bottom - that reads as a Markdown table).
+
+So the header anchors the table, the rule under it can be heavier than the frame (HeaderRule "heavy" or "double",
+for light and rounded frames) and rules between the rows (RowSeparator) light:
+
+	┌────────┬────────┐
+	│ Name   │ Status │
+	╞════════╪════════╡
+	│ node-1 │ Ready  │
+	├────────┼────────┤
+	│ node-2 │ Ready  │
+	└────────┴────────┘
+*/
+
+// frameLine is the characters for one of a frame's horizontal lines - the left end, the fill, where it meets a column separator, and the right end
+type frameLine struct {
+	left, fill, join, right string
+}
+
+type frame struct {
+	top, rule, bottom frameLine
+	edge              string // left and right sides
+}
+
+var frames = map[string]frame{
+	"light": {
+		top:    frameLine{"┌", "─", "┬", "┐"},
+		rule:   frameLine{"├", "─", "┼", "┤"},
+		bottom: frameLine{"└", "─", "┴", "┘"},
+		edge:   "│",
+	},
+	"rounded": {
+		top:    frameLine{"╭", "─", "┬", "╮"},
+		rule:   frameLine{"├", "─", "┼", "┤"},
+		bottom: frameLine{"╰", "─", "┴", "╯"},
+		edge:   "│",
+	},
+	"heavy": {
+		top:    frameLine{"┏", "━", "┳", "┓"},
+		rule:   frameLine{"┣", "━", "╋", "┫"},
+		bottom: frameLine{"┗", "━", "┻", "┛"},
+		edge:   "┃",
+	},
+	"double": {
+		top:    frameLine{"╔", "═", "╦", "╗"},
+		rule:   frameLine{"╠", "═", "╬", "╣"},
+		bottom: frameLine{"╚", "═", "╩", "╝"},
+		edge:   "║",
+	},
+	"ascii": {
+		top:    frameLine{"+", "-", "+", "+"},
+		rule:   frameLine{"+", "-", "+", "+"},
+		bottom: frameLine{"+", "-", "+", "+"},
+		edge:   "|",
+	},
+	"markdown": {
+		top:    frameLine{"|", "-", "|", "|"},
+		rule:   frameLine{"|", "-", "|", "|"},
+		bottom: frameLine{"|", "-", "|", "|"},
+		edge:   "|",
+	},
+}
+
+// heavier rules under the header, where the frame's characters allow them (by frame, then HeaderRule weight)
+var headerRules = map[string]map[string]frameLine{
+	"light": {
+		"heavy":  {"┝", "━", "┿", "┥"},
+		"double": {"╞", "═", "╪", "╡"},
+	},
+	"rounded": {
+		"heavy":  {"┝", "━", "┿", "┥"},
+		"double": {"╞", "═", "╪", "╡"},
+	},
+	"ascii": {
+		"heavy":  {"+", "=", "+", "+"},
+		"double": {"+", "=", "+", "+"},
+	},
+}
+
+// framer draws the frame around one page of columns
+type framer struct {
+	frame frame
+	width int   // width of the lines inside the frame
+	joins []int // positions (in the lines inside the frame) of the column separators
+	cols  []int // positions where each column after the first starts, the gap in front of it included
+	color string
+
+	headerRule frameLine // the rule under the header, the frame's own rule unless the theme asks for a heavier one
+}
+
+// framer returns the framer for the columns in cols, nil if the theme doesn't have a (known) frame
+func (ct *Table) framer(lay layout, cols []int) *framer {
+
+	name := ct.Theme.Frame
+	if ct.ASCII && name != "" && !strings.EqualFold(name, "markdown") {
+		name = "ascii"
+	}
+	fr, ok := frames[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+
+	f := &framer{frame: fr, color: ct.Theme.BorderColor, headerRule: fr.rule}
+	if rule, ok := headerRules[strings.ToLower(name)][strings.ToLower(ct.Theme.HeaderRule)]; ok {
+		f.headerRule = rule
+	}
+
+	gapWidth := textWidth(lay.gap)
+	for i, c := range cols {
+		if i > 0 {
+			f.cols = append(f.cols, f.width)
+			if ct.Theme.ColumnSeparator != "" {
+				f.joins = append(f.joins, f.width+ct.Theme.Padding)
+			}
+			f.width += gapWidth
+		}
+		f.width += lay.widths[c]
+	}
+	if w := lay.summaryWidth(); w > f.width {
+		f.width = w
+	}
+
+	return f
+}
+
+// frameWidth returns the width the frame adds to each line (the sides and a space inside each), 0 with no frame
+func (ct *Table) frameWidth() int {
+
+	if _, ok := frames[strings.ToLower(ct.Theme.Frame)]; !ok {
+		return 0
+	}
+
+	return 4
+}
+
+// line draws a horizontal line of the frame, with title (if any) let into it at the left
+func (f *framer) line(fl frameLine, title string) string {
+
+	var sb strings.Builder
+	sb.WriteString(fl.left + fl.fill)
+
+	joins := f.joins
+	for p := 0; p < f.width; p++ {
+		if len(joins) > 0 && joins[0] == p {
+			sb.WriteString(fl.join)
+			joins = joins[1:]
+		} else {
+			sb.WriteString(fl.fill)
+		}
+	}
+	sb.WriteString(fl.fill + fl.right)
+
+	line := sb.String()
+	if title = stripANSI(title); title != "" && f.width > 2 {
+		// " title " goes in after the corner and one fill character, cut short if it doesn't fit
+		title = " " + title + " "
+		if textWidth(title) > f.width {
+			title = truncateText(title, f.width)
+		}
+		runes := []rune(line)
+		line = string(runes[:2]) + title + string(runes[2+textWidth(title):])
+	}
+
+	return colorize(line, f.color)
+}
+
+// content puts a line of the table inside the frame
+func (f *framer) content(s string) string {
+	edge := colorize(f.frame.edge, f.color)
+	return edge + " " + padText(s, f.width, "left") + " " + edge
+}
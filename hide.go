@@ -0,0 +1,40 @@
+package ctable
+
+/*
+Hiding empty columns.
+
+Optional fields that are rarely set leave wide blank stripes across a table. With HideEmptyColumns set, columns whose
+every value is empty (or EmptyValue, the placeholder for nil values) are left out when the table is displayed. The
+data's still there, a column is shown again as soon as a row has a value in it.
+*/
+
+// hideEmptyColumns marks the columns with nothing to show as hidden in the layout
+func (ct *Table) hideEmptyColumns(lay *layout) {
+
+	if !ct.HideEmptyColumns {
+		return
+	}
+
+	hidden := make([]bool, ct.ColumnCount)
+	anyEmpty := false
+
+	for c := range ct.Columns {
+		// the first column carries the tree guides
+		if c == 0 && lay.prefixes != nil {
+			continue
+		}
+		empty := true
+		ct.scanColumn(c, func(value string) {
+			if value != "" && value != ct.EmptyValue {
+				empty = false
+			}
+		})
+		hidden[c] = empty
+		anyEmpty = anyEmpty || empty
+	}
+
+	// an empty table still shows its columns
+	if anyEmpty && ct.RowCount > 0 {
+		lay.hidden = hidden
+	}
+}
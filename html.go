@@ -0,0 +1,236 @@
+package ctable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+HTML output.
+
+RenderHTML() writes the table as an HTML <table>, for reports served or mailed as web pages. So the output drops
+straight into an existing page, CSS classes can be put on the table, on each column's cells, and on each row
+(worked out from the row's values), and the values of chosen columns can be carried on each row as data-* attributes
+for scripts to pick up:
+
+	err := ct.RenderHTML(w, ctable.HTMLOptions{
+		ShowHeaders:    true,
+		TableClass:     "report",
+		ColumnClasses:  map[string]string{"Size": "num"},
+		RowClass:       func(row []string) string { if row[2] == "down" { return "alert" }; return "" },
+		DataAttributes: []string{"Host", "Status"}, // <tr data-host="web-1" data-status="down">
+	})
+
+Columns that aren't left justified get a matching text-align style. Multiline values are split with <br>, values are
+written without any ANSI styling, in display order, and hidden columns (HideEmptyColumns) are left out.
+
+With Interactive set, a filter box goes above the table and a small script (plain JavaScript, nothing to load) goes with
+it - clicking a column name sorts the rows by that column (numerically when the values are numbers, clicking again
+reverses the order), and typing in the filter box shows just the rows containing the text. With Page set, the output is
+a whole self-contained HTML document (titled with the table's Title) rather than just the table, e.g. to turn a
+command's report into a page that can be opened in a browser.
+
+The markup is accessible as it stands: the table's Title is its <caption>, column names are <th scope="col"> (and with
+RowHeaders the first column's cells are <th scope="row">, for tables where it names each row), the filter box is
+labeled, and sortable column names can be sorted from the keyboard (Enter or Space) and carry aria-sort.
+*/
+
+type HTMLOptions struct {
+	ShowHeaders bool
+
+	TableClass     string                    // class of the <table>, "" for none
+	ColumnClasses  map[string]string         // class of the cells of each column, by column name
+	RowClass       func(row []string) string // class of each row's <tr> from its values (multiline values joined by newlines), nil for none
+	DataAttributes []string                  // columns whose values go on each row's <tr> as data-<column name> attributes
+
+	Interactive bool // sort by clicking the column names, and a filter box
+	Page        bool // a complete HTML document rather than just the table
+	RowHeaders  bool // the first column names the rows, its cells are row headers
+}
+
+// RenderHTML writes the table to w as an HTML table.
+func (ct *Table) RenderHTML(w io.Writer, opts HTMLOptions) error {
+
+	lay := ct.computeLayout()
+	cols := lay.columns()
+
+	dataColumns := []int{}
+	for _, name := range opts.DataAttributes {
+		c := ct.columnIndex(name)
+		if c < 0 {
+			return fmt.Errorf("CONSOLETABLE: no column named %q", name)
+		}
+		dataColumns = append(dataColumns, c)
+	}
+
+	// class and style attributes of each column's cells
+	cellAttrs := make([]string, ct.ColumnCount)
+	for _, c := range cols {
+		col := ct.Columns[c]
+		if class := opts.ColumnClasses[col.Name]; class != "" {
+			cellAttrs[c] += htmlAttr("class", class)
+		}
+		switch col.Justification {
+		case "right", "decimal":
+			cellAttrs[c] += htmlAttr("style", "text-align: right")
+		case "center":
+			cellAttrs[c] += htmlAttr("style", "text-align: center")
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if opts.Page {
+		title := stripANSI(ct.Title)
+		if title == "" {
+			title = "Table"
+		}
+		bw.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		bw.WriteString("<title>" + ct.escape("html", title) + "</title>\n")
+		bw.WriteString(htmlPageStyle)
+		bw.WriteString("</head>\n<body>\n")
+	}
+	if opts.Interactive {
+		bw.WriteString("<div class=\"ctable\">\n<input type=\"search\" placeholder=\"Filter\" aria-label=\"Filter rows\">\n")
+	}
+
+	bw.WriteString("<table" + htmlAttr("class", opts.TableClass) + ">\n")
+	if title := stripANSI(ct.Title); title != "" {
+		bw.WriteString("<caption>" + ct.escape("html", title) + "</caption>\n")
+	}
+
+	if opts.ShowHeaders {
+		bw.WriteString("<thead>\n<tr>")
+		for _, c := range cols {
+			bw.WriteString("<th scope=\"col\"" + cellAttrs[c] + ">" + ct.escape("html", ct.Columns[c].Name) + "</th>")
+		}
+		bw.WriteString("</tr>\n</thead>\n")
+	}
+
+	bw.WriteString("<tbody>\n")
+	for pos := 0; pos < lay.rows; pos++ {
+		row := ct.OutputRow(lay.rowAt(pos))
+
+		attrs := ""
+		if opts.RowClass != nil {
+			attrs += htmlAttr("class", opts.RowClass(row))
+		}
+		for _, c := range dataColumns {
+			attrs += htmlAttr("data-"+dataAttributeName(ct.Columns[c].Name), stripANSI(row[c]))
+		}
+
+		bw.WriteString("<tr" + attrs + ">")
+		for i, c := range cols {
+			if i == 0 && opts.RowHeaders {
+				bw.WriteString("<th scope=\"row\"" + cellAttrs[c] + ">" + ct.htmlText(row[c]) + "</th>")
+				continue
+			}
+			bw.WriteString("<td" + cellAttrs[c] + ">" + ct.htmlText(row[c]) + "</td>")
+		}
+		bw.WriteString("</tr>\n")
+	}
+	bw.WriteString("</tbody>\n</table>\n")
+
+	if opts.Interactive {
+		bw.WriteString(htmlInteractiveScript)
+		bw.WriteString("</div>\n")
+	}
+	if opts.Page {
+		bw.WriteString("</body>\n</html>\n")
+	}
+
+	return bw.Flush()
+}
+
+// htmlText returns a value as cell content - escaped, with its lines separated by <br>
+func (ct *Table) htmlText(value string) string {
+
+	lines := strings.Split(stripANSI(value), "\n")
+	for i := range lines {
+		lines[i] = ct.escape("html", lines[i])
+	}
+
+	return strings.Join(lines, "<br>")
+}
+
+// htmlAttr returns ` name="value"` (with value escaped), "" when value is ""
+func htmlAttr(name string, value string) string {
+
+	if value == "" {
+		return ""
+	}
+
+	return " " + name + `="` + htmlAttrEscaper.Replace(value) + `"`
+}
+
+var htmlAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;")
+
+// dataAttributeName returns a column name as the name of a data-* attribute - lower case, with anything other than
+// letters and digits as "-"
+func dataAttributeName(name string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name), "-")
+}
+
+// a plain look for whole pages, cell padding and lines between the rows
+const htmlPageStyle = `<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { padding: 0.25em 0.75em; text-align: left; border-bottom: 1px solid #ddd; }
+thead th { border-bottom: 2px solid #999; }
+input[type=search] { margin-bottom: 0.5em; }
+</style>
+`
+
+// sorting and filtering for Interactive tables, finds the table and filter box in the div around it
+const htmlInteractiveScript = `<script>
+(function () {
+	var box = document.currentScript.parentNode;
+	var table = box.querySelector("table"), filter = box.querySelector("input"), body = table.tBodies[0];
+	var each = function (list, fn) { Array.prototype.forEach.call(list, fn); };
+
+	filter.addEventListener("input", function () {
+		var needle = filter.value.toLowerCase();
+		each(body.rows, function (row) {
+			row.style.display = row.textContent.toLowerCase().indexOf(needle) < 0 ? "none" : "";
+		});
+	});
+
+	if (!table.tHead) {
+		return;
+	}
+	each(table.tHead.rows[0].cells, function (th, i) {
+		th.style.cursor = "pointer";
+		th.tabIndex = 0;
+		th.addEventListener("keydown", function (e) {
+			if (e.key === "Enter" || e.key === " ") {
+				e.preventDefault();
+				th.click();
+			}
+		});
+		th.addEventListener("click", function () {
+			var descending = th.getAttribute("aria-sort") === "ascending";
+			each(table.tHead.rows[0].cells, function (other) { other.removeAttribute("aria-sort"); });
+			var rows = Array.prototype.slice.call(body.rows);
+			rows.sort(function (a, b) {
+				var x = a.cells[i].textContent, y = b.cells[i].textContent;
+				var nx = Number(x), ny = Number(y);
+				var order = x !== "" && y !== "" && !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+				return descending ? -order : order;
+			});
+			each(rows, function (row) { body.appendChild(row); });
+			th.setAttribute("aria-sort", descending ? "descending" : "ascending");
+		});
+	});
+})();
+</script>
+`
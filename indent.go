@@ -0,0 +1,71 @@
+package ctable
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+/*
+Indentation and line decoration.
+
+Indent() shifts the whole printed table right, so it can sit under a bullet or section heading in larger output:
+
+	fmt.Println("Hosts:")
+	ct.Indent(2)
+	ct.Display(true)
+
+LinePrefix and LineSuffix go at the start and end of every printed line (after any indent), for tables quoted in
+comment blocks or written to structured logs:
+
+	ct.LinePrefix = "# "
+*/
+
+// Indent indents every line the table prints (Display(), DisplayPaged()) by n spaces, 0 for none.
+func (ct *Table) Indent(n int) {
+
+	if n < 0 {
+		n = 0
+	}
+
+	ct.indent = strings.Repeat(" ", n)
+}
+
+// printLine prints a line of the table's output, indented and with the line prefix and suffix
+// (blank lines are left blank when there's no prefix or suffix)
+func (ct *Table) printLine(s string) {
+
+	ct.clearProgress()
+	out := ct.writer()
+
+	if s == "" && ct.LinePrefix == "" && ct.LineSuffix == "" {
+		fmt.Fprintln(out)
+		return
+	}
+
+	line := ct.indent + ct.LinePrefix + s + ct.LineSuffix
+	if !ct.colorEnabled(out) {
+		line = stripANSI(line)
+	}
+	if ct.encoding != nil {
+		line = ct.encoding.encode(line)
+	}
+
+	fmt.Fprintln(out, line)
+}
+
+// writer returns where printed lines go
+func (ct *Table) writer() io.Writer {
+
+	if ct.output == nil {
+		return os.Stdout
+	}
+
+	return ct.output
+}
+
+// decorationWidth returns the width added to each line of the table by the indent, line prefix and suffix, and frame
+func (ct *Table) decorationWidth() int {
+	return len(ct.indent) + textWidth(ct.LinePrefix) + textWidth(ct.LineSuffix) + ct.frameWidth()
+}
@@ -0,0 +1,123 @@
+package ctable
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Column type inference.
+
+Imported data (FromMarkdown() etc.) arrives as text, so numbers come out left justified like everything else.
+InferTypes() looks at each column's values and sets the column's Type to "number", "bool", or "date" when all of its
+values are one (blank values and EmptyValue don't count either way), "" otherwise. Number columns are then right
+justified, lined up on the decimal point when any of the values has a fractional part, and bool columns centered.
+*/
+
+// layouts dates are recognised in
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+	time.RFC1123,
+}
+
+// InferTypes sets each column's Type from its values, and justifies it to suit (columns that have a justification
+// other than the default keep it).
+func (ct *Table) InferTypes() {
+
+	justify := make([]bool, ct.ColumnCount)
+	for c, col := range ct.Columns {
+		justify[c] = col.Justification == defaultJustification()
+	}
+
+	ct.inferTypes(justify)
+}
+
+// inferTypes is InferTypes() justifying just the columns set in justify
+func (ct *Table) inferTypes(justify []bool) {
+
+	for c := range ct.Columns {
+		col := &ct.Columns[c]
+		kind, fractions := ct.columnType(c)
+		col.Type = kind
+
+		if !justify[c] {
+			continue
+		}
+		switch {
+		case kind == "number" && fractions:
+			col.Justification = "decimal"
+		case kind == "number":
+			col.Justification = "right"
+		case kind == "bool":
+			col.Justification = "center"
+		}
+	}
+
+	ct.windowLayout = nil
+}
+
+// columnType returns the type of the values in column c ("" when they're not all the same type, or there aren't any),
+// and for numbers whether any have a fractional part
+func (ct *Table) columnType(c int) (kind string, fractions bool) {
+
+	number, boolean, date := true, true, true
+	seen := false
+
+	ct.scanColumn(c, func(value string) {
+		value = strings.TrimSpace(stripANSI(value))
+		if value == "" || value == ct.EmptyValue {
+			return
+		}
+		seen = true
+
+		if number {
+			// ParseFloat takes "NaN", "Inf" and "infinity" too, which are words as far as a column's concerned
+			if n, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+				number = false
+			} else if strings.ContainsAny(value, ".eE") {
+				fractions = true
+			}
+		}
+		if boolean {
+			switch strings.ToLower(value) {
+			case "true", "false", "yes", "no":
+			default:
+				boolean = false
+			}
+		}
+		if date {
+			date = isDate(value)
+		}
+	})
+
+	switch {
+	case !seen:
+		return "", false
+	case number:
+		return "number", fractions
+	case boolean:
+		return "bool", false
+	case date:
+		return "date", false
+	}
+
+	return "", false
+}
+
+// isDate reports whether value is a date (or date and time) in one of dateLayouts
+func isDate(value string) bool {
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
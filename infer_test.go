@@ -0,0 +1,32 @@
+package ctable
+
+import (
+	"testing"
+)
+
+func TestInferTypes(t *testing.T) {
+
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "2.5", ""}, "number"},
+		{[]string{"yes", "No"}, "bool"},
+		{[]string{"2024-01-02", "2024/03/04"}, "date"},
+		{[]string{"NaN"}, ""},
+		{[]string{"1", "inf"}, ""},
+		{[]string{"-Infinity", "2"}, ""},
+		{[]string{"1", "n/a"}, ""},
+	}
+
+	for _, tt := range tests {
+		ct := NewTable([]Column{NewColumn("Value", 0)})
+		for _, value := range tt.values {
+			ct.AddRow(value)
+		}
+		ct.InferTypes()
+		if got := ct.Columns[0].Type; got != tt.want {
+			t.Errorf("%q: got type %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package ctable
+
+/*
+Justification.
+
+Each column's values are justified left (the default, see SetDefaults()), right, centered (the padding split evenly
+either side), or "decimal" - right justified with the decimal points lined up, see decimal.go. Column names in the header
+are left justified unless the column has a HeaderJustification:
+
+	ct.Columns[1].Justification = ctable.JustifyCenter
+	ct.Columns[1].HeaderJustification = ctable.JustifyCenter
+
+Justification is a string type, so "left", "right", "center", and "decimal" work as well (as in configs and struct tags).
+
+A cell can be justified differently from the rest of its column, e.g. a centered "—" standing in for a missing value
+in a right justified numeric column:
+
+	ct.AddRow("disk3", "—")
+	ct.JustifyCell(ct.RowCount-1, 1, "center")
+
+Cells in a "decimal" column given their own justification aren't lined up on the decimal point.
+*/
+
+type Justification string
+
+const (
+	JustifyLeft    Justification = "left"
+	JustifyRight   Justification = "right"
+	JustifyCenter  Justification = "center"
+	JustifyDecimal Justification = "decimal"
+)
+
+// JustifyCell overrides the justification of field col of row, "" goes back to the column's.
+func (ct *Table) JustifyCell(row int, col int, justification Justification) {
+
+	if row < 0 || row >= ct.RowCount || col < 0 || col >= ct.ColumnCount {
+		ct.fatal("JustifyCell() row or column index is out of range.")
+		return
+	}
+
+	if ct.cellJustifications == nil {
+		ct.cellJustifications = map[int]Justification{}
+	}
+
+	first, end := ct.rowLines(row)
+	for l := first; l < end; l++ {
+		if justification == "" {
+			delete(ct.cellJustifications, l*ct.ColumnCount+col)
+		} else {
+			ct.cellJustifications[l*ct.ColumnCount+col] = justification
+		}
+	}
+	ct.windowLayout = nil
+}
+
+// justification returns the justification of field c of display line l, its own if it has one, otherwise the column's
+func (ct *Table) justification(l int, c int) Justification {
+
+	if j, ok := ct.cellJustifications[l*ct.ColumnCount+c]; ok {
+		return j
+	}
+
+	return ct.Columns[c].Justification
+}
+
+// headerJustification returns the justification of column c's name in the header (decimal columns' names go right)
+func (ct *Table) headerJustification(c int) Justification {
+
+	switch j := ct.Columns[c].HeaderJustification; j {
+	case "":
+		return JustifyLeft
+	case JustifyDecimal:
+		return JustifyRight
+	default:
+		return j
+	}
+}
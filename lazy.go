@@ -0,0 +1,108 @@
+package ctable
+
+/*
+Lazy cells.
+
+A field value that's expensive to come by (a reverse DNS lookup, an API call) can be added as a CellProvider, or a plain
+func() string, instead of the value itself. It's only called when the value is first needed - when the row is displayed,
+exported, or read with Row() - so rows that never are (rows in collapsed groups, say) don't pay for it:
+
+	ct.AddRow(ip, func() string { return reverseLookup(ip) })
+
+Each provider is called at most once, its value is kept. A lazy value is a single line, and it's shown like any other
+value once it's been worked out - until then it's blank to anything that only scans the columns (decimal alignment,
+HideEmptyColumns, InferTypes() etc.), and the column is sized without it. OnRowAdded() handlers get it blank as well.
+*/
+
+// CellProvider supplies a field value when it's needed.
+type CellProvider interface {
+	CellValue() string
+}
+
+// CellProviderFunc makes a plain function a CellProvider.
+type CellProviderFunc func() string
+
+// CellValue calls f.
+func (f CellProviderFunc) CellValue() string {
+	return f()
+}
+
+// takeProviders replaces the lazy values among fields with blanks, and returns them by column (nil if there aren't any)
+func takeProviders(fields []interface{}) map[int]CellProvider {
+
+	var providers map[int]CellProvider
+	for i, field := range fields {
+		if p, ok := field.(CellProvider); ok {
+			if providers == nil {
+				providers = map[int]CellProvider{}
+			}
+			providers[i] = p
+			fields[i] = ""
+		}
+	}
+
+	return providers
+}
+
+// storeProviders keeps the lazy values of the fields of display line l until they're needed
+func (ct *Table) storeProviders(l int, providers map[int]CellProvider) {
+
+	if ct.lazyCells == nil {
+		ct.lazyCells = map[int]CellProvider{}
+	}
+	for c, p := range providers {
+		ct.lazyCells[l*ct.ColumnCount+c] = p
+	}
+}
+
+// resolveCell works out the lazy value of field c of display line l, if it has one that hasn't been worked out yet
+func (ct *Table) resolveCell(l int, c int) {
+
+	key := l*ct.ColumnCount + c
+	p, ok := ct.lazyCells[key]
+	if !ok {
+		return
+	}
+	delete(ct.lazyCells, key)
+	if len(ct.lazyCells) == 0 {
+		ct.lazyCells = nil
+	}
+
+	value := p.CellValue()
+	ct.setCell(l, c, value)
+
+	col := &ct.Columns[c]
+	if w := textWidth(value); w > col.maxLength {
+		col.maxLength = w
+		col.setTruncateAt(col.truncateAt)
+	}
+}
+
+// resolveLine works out the lazy values of display line l
+func (ct *Table) resolveLine(l int) {
+	for c := 0; c < ct.ColumnCount; c++ {
+		ct.resolveCell(l, c)
+	}
+}
+
+// resolveDisplayed works out the lazy values of the rows lay displays (before the widths are worked out, so they count)
+func (ct *Table) resolveDisplayed(lay layout) {
+
+	if len(ct.lazyCells) == 0 {
+		return
+	}
+
+	rows := ct.RowCount
+	if lay.rowOrder != nil {
+		rows = len(lay.rowOrder)
+	}
+	for pos := 0; pos < rows; pos++ {
+		first, end := ct.rowLines(lay.rowAt(pos))
+		if _, ok := lay.summaries[first]; ok {
+			continue
+		}
+		for l := first; l < end; l++ {
+			ct.resolveLine(l)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package ctable
+
+import (
+	"testing"
+)
+
+func TestLazyCellsOnRowAdded(t *testing.T) {
+
+	calls := 0
+	lookup := func() string {
+		calls++
+		return "web-1.example.com"
+	}
+
+	ct := NewTable([]Column{NewColumn("IP", 0), NewColumn("Host", 0)})
+	var added []string
+	ct.OnRowAdded(func(i int, row []string) { added = row })
+	ct.AddRow("10.0.0.1", lookup)
+
+	if calls != 0 {
+		t.Errorf("OnRowAdded() worked out the lazy value")
+	}
+	if added[0] != "10.0.0.1" || added[1] != "" {
+		t.Errorf("handler got %q, want the IP and a blank host", added)
+	}
+
+	if got := ct.Row(0)[1]; got != "web-1.example.com" || calls != 1 {
+		t.Errorf("Row() got %q after %d calls, want the host after 1", got, calls)
+	}
+	ct.Row(0)
+	if calls != 1 {
+		t.Errorf("the lazy value was worked out %d times", calls)
+	}
+}
@@ -0,0 +1,75 @@
+package ctable
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+Go source literals.
+
+RenderGoLiteral() returns the table, exactly as Display() would print it, as a Go string literal ready to paste into a
+test as the expected output:
+
+	fmt.Println(ct.RenderGoLiteral(true))
+
+	// prints
+	`Name  Size
+	===== ====
+	a.txt 12
+	`
+
+A raw (backquoted) literal is used when the output can be written as one, otherwise (backquotes, carriage returns,
+ANSI colors or other control characters in the output) it's an interpreted string literal, one line of output per
+source line.
+*/
+
+// RenderGoLiteral returns what Display(showHeaders) prints as a Go string literal.
+func (ct *Table) RenderGoLiteral(showHeaders bool) string {
+
+	var sb strings.Builder
+	ct.Fprint(&sb, showHeaders)
+
+	return goLiteral(sb.String())
+}
+
+// goLiteral returns s as a Go string literal, raw if possible
+func goLiteral(s string) string {
+
+	if canBackquote(s) {
+		return "`" + s + "`"
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return `""`
+	}
+
+	quoted := make([]string, len(lines))
+	for i, line := range lines {
+		quoted[i] = strconv.Quote(line)
+	}
+
+	return strings.Join(quoted, " +\n\t")
+}
+
+// canBackquote reports whether s can be written as a raw string literal that reads the same in source - no backquotes,
+// no carriage returns (which the compiler drops from raw strings), and nothing else unprintable apart from tabs and newlines
+func canBackquote(s string) bool {
+
+	for _, r := range s {
+		switch {
+		case r == '`', r == '\r', r == unicode.ReplacementChar:
+			return false
+		case r == '\n', r == '\t':
+		case !unicode.IsPrint(r) && r != ' ':
+			return false
+		}
+	}
+
+	return true
+}
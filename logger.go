@@ -0,0 +1,55 @@
+package ctable
+
+import (
+	"log"
+	"os"
+)
+
+/*
+Diagnostics.
+
+Misuse (wrong number of fields, unsupported field types, unknown column names etc.) is reported through a Logger rather
+than straight to the standard logger, so a library embedding ctable decides where the messages go and what happens next.
+The default writes to stderr and exits, like log.Fatal(). A *log.Logger satisfies the interface, e.g.
+
+	ctable.SetLogger(log.New(logFile, "mytool: ", log.LstdFlags))
+
+A Logger whose Fatal() returns (records the error, panics to be recovered etc.) is fine too, the call that failed
+is abandoned without changing the table.
+*/
+
+// Logger receives the package's diagnostics.
+type Logger interface {
+	Print(v ...interface{}) // something worth knowing about that doesn't stop the table being used
+	Fatal(v ...interface{}) // misuse that the call can't continue from, expected not to return (but it may)
+}
+
+var (
+	defaultLogger Logger = log.New(os.Stderr, "", log.LstdFlags)
+	packageLogger        = defaultLogger
+)
+
+// SetLogger sets the Logger used by tables that don't have their own (Table.Logger), nil puts back the default (stderr).
+func SetLogger(l Logger) {
+
+	if l == nil {
+		l = defaultLogger
+	}
+
+	packageLogger = l
+}
+
+// logger returns the Logger for the table's diagnostics
+func (ct *Table) logger() Logger {
+
+	if ct.Logger != nil {
+		return ct.Logger
+	}
+
+	return packageLogger
+}
+
+// fatal reports misuse the current call can't continue from, callers have to return if it does
+func (ct *Table) fatal(msg string) {
+	ct.logger().Fatal("CONSOLETABLE: " + msg)
+}
@@ -0,0 +1,44 @@
+package ctable
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testLogger records the diagnostics, its Fatal() returns
+type testLogger struct {
+	fatals []string
+}
+
+func (l *testLogger) Print(v ...interface{}) {}
+
+func (l *testLogger) Fatal(v ...interface{}) {
+	l.fatals = append(l.fatals, fmt.Sprint(v...))
+}
+
+func TestFailedCallLeavesTable(t *testing.T) {
+
+	logger := &testLogger{}
+	ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("N", 0)})
+	ct.Logger = logger
+
+	ct.AddRow("root", "1")
+	if r := ct.AddChildRow(0, "too", "many", "fields"); r != -1 {
+		t.Errorf("AddChildRow() with the wrong number of fields returned %d, want -1", r)
+	}
+	ct.AddChildRow(0, "child", "2")
+	ct.AddRow("root2", "3")
+
+	want := `Name     N
+======== =
+root     1
+└─ child 2
+root2    3
+`
+	if got := ct.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if len(logger.fatals) != 1 {
+		t.Errorf("got %d fatal diagnostics, want 1", len(logger.fatals))
+	}
+}
@@ -0,0 +1,228 @@
+package ctable
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+/*
+Markdown tables.
+
+FromMarkdown() reads a GitHub flavored Markdown pipe table into a Table, so tables kept in docs can be shown with the
+same console tooling:
+
+	| Name   | Size |
+	|--------|-----:|
+	| a.txt  |   12 |
+
+The alignment row sets each column's justification (":--" left, "--:" right, ":-:" center), columns with plain dashes
+are justified to suit the values in them (numbers right etc., see InferTypes()). "<br>" in a cell splits it
+into a multiline value, and "\|" is a literal pipe. Anything before the table (a heading, text) is skipped, the table
+ends at the first line that isn't a table row.
+
+RenderMarkdown() goes the other way, writing the table as a Markdown table for docs, READMEs, and PR comments - the
+alignment row from each column's justification ("decimal" as right), pipes in values escaped, multiline values joined
+with "<br>", and the columns padded so the table reads as well in the source as rendered. Values are written without any
+ANSI styling, in display order, and hidden columns (HideEmptyColumns) are left out.
+*/
+
+// FromMarkdown creates a table from the first Markdown pipe table in r.
+func FromMarkdown(r io.Reader) (Table, error) {
+
+	scanner := bufio.NewScanner(r)
+
+	var header []string
+	var justifications []Justification
+	var rows [][]string
+	previous := ""
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if header == nil {
+			// the header is the line before the first alignment row
+			if cells := splitMarkdownRow(previous); previous != "" && len(cells) > 0 {
+				if j, ok := markdownAlignment(line, len(cells)); ok {
+					header, justifications = cells, j
+					continue
+				}
+			}
+			previous = line
+			continue
+		}
+
+		if !strings.Contains(line, "|") {
+			break
+		}
+		rows = append(rows, splitMarkdownRow(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return Table{}, err
+	}
+	if header == nil {
+		return Table{}, errors.New("CONSOLETABLE: no Markdown table found")
+	}
+
+	columns := make([]Column, len(header))
+	infer := make([]bool, len(header))
+	for i, name := range header {
+		columns[i] = NewColumn(name, 0)
+		if justifications[i] != "" {
+			columns[i].Justification = justifications[i]
+		}
+		infer[i] = justifications[i] == ""
+	}
+	ct := NewTable(columns)
+
+	for _, cells := range rows {
+		fields := make([]interface{}, len(header))
+		for i := range fields {
+			value := ""
+			if i < len(cells) {
+				value = cells[i]
+			}
+			if lines := strings.Split(lineBreaks.Replace(value), "<br>"); len(lines) > 1 {
+				fields[i] = lines
+			} else {
+				fields[i] = value
+			}
+		}
+		ct.AddRow(fields...)
+	}
+
+	ct.inferTypes(infer)
+
+	return ct, nil
+}
+
+// RenderMarkdown writes the table to w as a Markdown table.
+func (ct *Table) RenderMarkdown(w io.Writer) error {
+
+	lay := ct.computeLayout()
+	cols := lay.columns()
+
+	// the cells as written, the header first
+	cells := [][]string{make([]string, len(cols))}
+	for i, c := range cols {
+		cells[0][i] = ct.escape("markdown", stripANSI(ct.Columns[c].Name))
+	}
+	for pos := 0; pos < lay.rows; pos++ {
+		row := ct.OutputRow(lay.rowAt(pos))
+		line := make([]string, len(cols))
+		for i, c := range cols {
+			line[i] = ct.escape("markdown", stripANSI(row[c]))
+		}
+		cells = append(cells, line)
+	}
+
+	// (3 is the least the alignment row can be written in, ":-:")
+	widths := make([]int, len(cols))
+	for i := range widths {
+		widths[i] = 3
+		for _, line := range cells {
+			if w := textWidth(line[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	alignment := make([]string, len(cols))
+	for i, c := range cols {
+		switch ct.Columns[c].Justification {
+		case "right", "decimal":
+			alignment[i] = strings.Repeat("-", widths[i]-1) + ":"
+		case "center":
+			alignment[i] = ":" + strings.Repeat("-", widths[i]-2) + ":"
+		default:
+			alignment[i] = ":" + strings.Repeat("-", widths[i]-1)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	writeRow := func(fields []string, justify bool) {
+		bw.WriteString("|")
+		for i, field := range fields {
+			j := JustifyLeft
+			if justify {
+				j = ct.Columns[cols[i]].Justification
+				if j == JustifyDecimal {
+					j = JustifyRight
+				}
+			}
+			bw.WriteString(" " + padText(field, widths[i], j) + " |")
+		}
+		bw.WriteString("\n")
+	}
+
+	writeRow(cells[0], false)
+	writeRow(alignment, false)
+	for _, line := range cells[1:] {
+		writeRow(line, true)
+	}
+
+	return bw.Flush()
+}
+
+// the ways of writing a line break in a cell
+var lineBreaks = strings.NewReplacer("<br/>", "<br>", "<br />", "<br>")
+
+// splitMarkdownRow splits a table row into its (trimmed) cells, nil if line isn't a table row
+func splitMarkdownRow(line string) []string {
+
+	if !strings.Contains(line, "|") {
+		return nil
+	}
+
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	cells := []string{}
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// markdownAlignment parses the alignment row under the header, ok is false if line isn't one (for a table of n columns).
+// Columns without an alignment get "".
+func markdownAlignment(line string, n int) (justifications []Justification, ok bool) {
+
+	cells := splitMarkdownRow(line)
+	if len(cells) != n {
+		return nil, false
+	}
+
+	for _, cell := range cells {
+		dashes := strings.Trim(cell, ":")
+		if dashes == "" || strings.Trim(dashes, "-") != "" {
+			return nil, false
+		}
+		switch left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":"); {
+		case left && right:
+			justifications = append(justifications, "center")
+		case right:
+			justifications = append(justifications, "right")
+		case left:
+			justifications = append(justifications, "left")
+		default:
+			justifications = append(justifications, "")
+		}
+	}
+
+	return justifications, true
+}
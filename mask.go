@@ -0,0 +1,77 @@
+package ctable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+/*
+Masking.
+
+Tables of accounts, credentials, or customer records can hold values that shouldn't end up on screen or in an exported
+file. A column's Mask hides its values wherever the table is output - Display() and the other console output, and the
+exports (TSV, JSON, CSV, HTML etc.) - while the table itself keeps them as they are, for Row(), RawValue(), sorting and
+the rest:
+
+	"full"   every value shown as ********
+	"last4"  all but the last four characters hidden - ****4242
+	"hash"   a short hash of the value - 9f86d081 - so equal values can still be matched up without being shown
+
+Blank values (and EmptyValue) aren't masked, there's nothing to hide. The column is sized to the masked values, and
+it's left out of any Footer (a range or sparkline would give the values away).
+*/
+
+// masked returns value (field of column c) as it's output, masked per the column's Mask
+func (ct *Table) masked(c int, value string) string {
+
+	mask := ct.Columns[c].Mask
+	if mask == "" || strings.TrimSpace(value) == "" || value == ct.EmptyValue {
+		return value
+	}
+
+	value = stripANSI(value)
+
+	switch mask {
+	case "last4":
+		runes := []rune(value)
+		if len(runes) <= 4 {
+			return "****"
+		}
+		return "****" + string(runes[len(runes)-4:])
+	case "hash":
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:4])
+	}
+
+	return "********"
+}
+
+// OutputRow returns the field values of row i as they're output - Row() with the column masks applied.
+func (ct *Table) OutputRow(i int) []string {
+
+	row := ct.Row(i)
+	for c := range row {
+		if ct.Columns[c].Mask == "" {
+			continue
+		}
+		lines := strings.Split(row[c], "\n")
+		for l := range lines {
+			lines[l] = ct.masked(c, lines[l])
+		}
+		row[c] = strings.Join(lines, "\n")
+	}
+
+	return row
+}
+
+// outputLine returns the fields of display line l as they're output
+func (ct *Table) outputLine(l int) []string {
+
+	fields := append([]string(nil), ct.line(l)...)
+	for c := range fields {
+		fields[c] = ct.masked(c, fields[c])
+	}
+
+	return fields
+}
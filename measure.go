@@ -0,0 +1,183 @@
+package ctable
+
+/*
+Layout without output.
+
+Layout() runs the same layout pass Display() does and hands back the result instead of printing, so a caller can
+look at how big the table will be first - and switch to DisplayPaged(), View(), or a pager when it won't fit.
+ColumnWidths() is the short version for lining up output of your own with the columns.
+
+	lay := ct.Layout()
+	if lay.TotalWidth > width {
+		...
+	}
+*/
+
+// TableLayout describes how the table will be laid out when displayed.
+type TableLayout struct {
+	ColumnWidths []int // width of each column (not counting the gaps between them)
+	TotalWidth   int   // width of a full line, gaps included
+	GapWidth     int   // width of the gap between two columns
+	RowLines     []int // display lines each row takes (by row index), 0 for rows hidden in collapsed groups
+	Lines        int   // display lines of data in all, not counting the header
+}
+
+// Layout computes the table's layout, without displaying anything.
+func (ct *Table) Layout() TableLayout {
+	return ct.exportLayout(ct.computeLayout())
+}
+
+func (ct *Table) exportLayout(lay layout) TableLayout {
+
+	tl := TableLayout{
+		ColumnWidths: append([]int(nil), lay.widths...),
+		TotalWidth:   lay.totalWidth(),
+		GapWidth:     textWidth(lay.gap),
+		RowLines:     make([]int, ct.RowCount),
+		Lines:        lay.lines,
+	}
+
+	for i := 0; i < lay.rows; i++ {
+		r := lay.rowAt(i)
+		tl.RowLines[r] = len(ct.appendRowLines(nil, lay, r))
+	}
+
+	return tl
+}
+
+// ColumnWidths returns the width each column is displayed at, so output printed around the table (underlines,
+// footers, notes lined up under a column) can match it exactly.
+func (ct *Table) ColumnWidths() []int {
+	return ct.computeLayout().widths
+}
+
+// Size returns the width and height (in characters and lines) Display(showHeaders) will print the table at,
+// header and header separator lines, frame or title, multiline values, collapsed groups, annotations, and StackWhenWide stacking included
+// (just the EmptyMessage line for an empty table with one).
+func (ct *Table) Size(showHeaders bool) (width int, height int) {
+
+	if ct.IsEmpty() && ct.EmptyMessage != "" {
+		return ct.emptyWidth(), 1
+	}
+
+	lay := ct.computeLayout()
+
+	pages := [][]int{lay.columns()}
+
+	if ct.StackWhenWide && lay.totalWidth() > ct.availableWidth() {
+		pages = ct.columnPages(lay, ct.availableWidth(), ct.columnIndex(ct.StackKeyColumn))
+	}
+
+	headerLines := 0
+	if showHeaders {
+		headerLines = 1
+		if ct.Theme.HeaderSeparator != "" {
+			headerLines++
+		}
+		if ct.Theme.SpaceHeader {
+			headerLines++
+		}
+	}
+
+	for p, cols := range pages {
+		if p > 0 {
+			height++ // blank line between stacked pages
+		}
+		height += headerLines + lay.lines + ct.footerHeight(lay)
+		if ct.Theme.RowSeparator != "" && lay.rows > 1 {
+			height += lay.rows - 1
+		}
+		if ct.frameWidth() > 0 && !ct.Theme.OpenFrame {
+			height += 2 // top and bottom of the frame
+		} else if ct.Title != "" {
+			height++
+		}
+
+		pageWidth := 0
+		for i, c := range cols {
+			if i > 0 {
+				pageWidth += textWidth(lay.gap)
+			}
+			pageWidth += lay.widths[c]
+		}
+		if pageWidth > width {
+			width = pageWidth
+		}
+	}
+
+	if w := lay.summaryWidth(); w > width {
+		width = w
+	}
+	if w := ct.annotationWidth(lay); w > width {
+		width = w
+	}
+	for _, line := range ct.abbreviationLegend(lay) {
+		if w := textWidth(line); w > width {
+			width = w
+		}
+		height++
+	}
+
+	width += ct.decorationWidth()
+	if w := ct.titleWidth(); w > width {
+		width = w
+	}
+
+	return width, height
+}
+
+// titleWidth returns the width of the title's line when it's printed on a line of its own (no frame to let it into,
+// or an OpenFrame), 0 when it isn't
+func (ct *Table) titleWidth() int {
+
+	if ct.Title == "" || ct.frameWidth() > 0 && !ct.Theme.OpenFrame {
+		return 0
+	}
+
+	return textWidth(ct.Title) + ct.decorationWidth() - ct.frameWidth()
+}
+
+// WillFit reports whether the table's lines (its title's included) fit in width characters as things stand (column truncation, MinWidth, theme, Indent(), LinePrefix/LineSuffix),
+// and if not, how many characters too wide they are. StackWhenWide isn't taken into account, this is about the table as one piece.
+func (ct *Table) WillFit(width int) (fits bool, overflow int) {
+
+	lineWidth := ct.emptyWidth()
+	if !ct.IsEmpty() || ct.EmptyMessage == "" {
+		lay := ct.computeLayout()
+		lineWidth = lay.totalWidth()
+		if w := lay.summaryWidth(); w > lineWidth {
+			lineWidth = w
+		}
+		if w := ct.annotationWidth(lay); w > lineWidth {
+			lineWidth = w
+		}
+		lineWidth += ct.decorationWidth()
+		if w := ct.titleWidth(); w > lineWidth {
+			lineWidth = w
+		}
+	}
+
+	if lineWidth <= width {
+		return true, 0
+	}
+
+	return false, lineWidth - width
+}
+
+// emptyWidth returns the width of the EmptyMessage line printed in place of an empty table (see empty.go)
+func (ct *Table) emptyWidth() int {
+	return len(ct.indent) + textWidth(ct.LinePrefix+ct.EmptyMessage+ct.LineSuffix)
+}
+
+// summaryWidth returns the width of the widest collapsed group summary, which aren't held to the column widths
+func (lay layout) summaryWidth() int {
+
+	width := 0
+	for l, summary := range lay.summaries {
+		if w := textWidth(lay.prefixes[l] + summary); w > width {
+			width = w
+		}
+	}
+
+	return width
+}
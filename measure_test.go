@@ -0,0 +1,54 @@
+package ctable
+
+import (
+	"strings"
+	"testing"
+)
+
+// renderedSize returns the width and height of the lines s
+func renderedSize(s string) (width int, height int) {
+
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		if w := textWidth(line); w > width {
+			width = w
+		}
+		height++
+	}
+
+	return width, height
+}
+
+func TestSizeMatchesOutput(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		setup func(ct *Table)
+	}{
+		{"plain", func(ct *Table) {}},
+		{"framed title", func(ct *Table) { ct.Theme.Frame = "rounded"; ct.Title = "A title wider than the table" }},
+		{"empty message", func(ct *Table) { ct.clearRows(); ct.EmptyMessage = "No resources found here." }},
+		{"annotated", func(ct *Table) { ct.Annotate(0, "connection refused on :443 (3 attempts)"); ct.Verbose = true }},
+		{"annotated framed", func(ct *Table) {
+			ct.Annotate(1, "connection refused on :443 (3 attempts)")
+			ct.Verbose = true
+			ct.Theme.Frame = "light"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("N", 0)})
+			ct.AddRow("web-1", "1")
+			ct.AddRow("db-1", "2")
+			tt.setup(&ct)
+
+			wantWidth, wantHeight := renderedSize(ct.String())
+			if width, height := ct.Size(true); width != wantWidth || height != wantHeight {
+				t.Errorf("Size() = %dx%d, output is %dx%d", width, height, wantWidth, wantHeight)
+			}
+			if fits, overflow := ct.WillFit(wantWidth - 1); fits || overflow != 1 {
+				t.Errorf("WillFit(%d) = %v, %d, want false, 1", wantWidth-1, fits, overflow)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Merged cells.
+
+Setting Merge on a column (typically a key column in a sorted listing) merges a run of rows with the same value in
+that column into a single cell - the value is shown once, at the top, and the rules between those rows (RowSeparator)
+leave the column open so the cell spans them:
+
+	┌───────────┬───────┐
+	│ Region    │ Host  │
+	├───────────┼───────┤
+	│ eu-west-1 │ web-1 │
+	│           ├───────┤
+	│           │ web-2 │
+	├───────────┼───────┤
+	│ us-east-1 │ web-3 │
+	└───────────┴───────┘
+
+Without rules between the rows, merging looks the same as Ditto with a blank DittoMark (see ditto.go).
+*/
+
+// mergedColumns returns which of the columns in cols are merged across the rule in front of display line l
+// (the first line of a row), nil if none are
+func (ct *Table) mergedColumns(l int, cols []int, lay layout) []bool {
+
+	var merged []bool
+	for i, c := range cols {
+		if _, ok := lay.dittos[l*ct.ColumnCount+c]; ok && ct.Columns[c].Merge {
+			if merged == nil {
+				merged = make([]bool, len(cols))
+			}
+			merged[i] = true
+		}
+	}
+
+	return merged
+}
+
+// mergedRule returns the rule between two rows with the merged columns left open, f is the page's framer (nil when it's not framed)
+func (ct *Table) mergedRule(f *framer, merged []bool, cols []int, lay layout) string {
+
+	if f != nil {
+		return f.mergedLine(f.frame.rule, merged)
+	}
+
+	rule := ct.separator(ct.Theme.RowSeparator)
+	line := ""
+	for i, c := range cols {
+		if i > 0 {
+			line += lay.headerGap
+		}
+		if merged[i] {
+			line += strings.Repeat(" ", lay.widths[c])
+		} else {
+			line += repeatToWidth(rule, lay.widths[c])
+		}
+	}
+
+	return colorize(line, ct.Theme.BorderColor)
+}
+
+// mergedLine draws a horizontal line of the frame with the parts under the merged columns left blank,
+// and the joins either side of them turned into the matching tees (or the plain column separator)
+func (f *framer) mergedLine(fl frameLine, merged []bool) string {
+
+	fill := func(i int) string {
+		if merged[i] {
+			return " "
+		}
+		return fl.fill
+	}
+
+	var sb strings.Builder
+	if merged[0] {
+		sb.WriteString(f.frame.edge)
+	} else {
+		sb.WriteString(fl.left)
+	}
+	sb.WriteString(fill(0))
+
+	col := 0
+	joins, starts := f.joins, f.cols
+	for p := 0; p < f.width; p++ {
+		if len(joins) > 0 && joins[0] == p {
+			switch left, right := merged[col], merged[col+1]; {
+			case left && right:
+				sb.WriteString(f.frame.edge)
+			case left:
+				sb.WriteString(fl.left)
+			case right:
+				sb.WriteString(fl.right)
+			default:
+				sb.WriteString(fl.join)
+			}
+			joins = joins[1:]
+			col++
+			continue
+		}
+		// without column separators each column's part starts with the gap in front of it
+		if f.joins == nil && len(starts) > 0 && starts[0] == p {
+			starts = starts[1:]
+			col++
+		}
+		sb.WriteString(fill(col))
+	}
+
+	sb.WriteString(fill(col))
+	if merged[col] {
+		sb.WriteString(f.frame.edge)
+	} else {
+		sb.WriteString(fl.right)
+	}
+
+	return colorize(sb.String(), f.color)
+}
@@ -0,0 +1,117 @@
+package ctable
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+Built-in paging.
+
+A table longer than the terminal scrolls its top off the screen, header and all. With Paginate set, Display() on a
+terminal shows a screenful at a time instead, more(1) style, with the header at the top of every screen and a prompt
+at the bottom:
+
+	-- More (q to quit) --
+
+Space (or page down) shows the next screen, enter (or down) one more line, q (or escape) stops there. Tables that fit on
+the screen, output that isn't going to a terminal, and tables StackWhenWide breaks up are displayed as they'd be without
+Paginate, as is everything when the terminal can't be put into raw mode to read the keys.
+*/
+
+const morePrompt = "-- More (q to quit) --"
+
+// displayPaginated displays the table a screenful at a time, false (with nothing printed) if it can't or needn't be
+func (ct *Table) displayPaginated(showHeaders bool, lay layout) bool {
+
+	out, ok := outputFile(ct.writer())
+	if !ok || !isTerminal(out) || !isTerminal(os.Stdin) {
+		return false
+	}
+	_, height, err := terminalSize(out)
+	if err != nil {
+		return false
+	}
+
+	cols := lay.columns()
+	f := ct.framer(lay, cols)
+	top := captureLines(ct, func() { ct.printTop(showHeaders, lay, cols, f) })
+	body := captureLines(ct, func() {
+		ct.printLines(lay, cols, f, false)
+		ct.printFooter(lay, cols, f)
+	})
+	end := captureLines(ct, func() {
+		ct.printBottom(f)
+		ct.printLegend(lay)
+	})
+	if len(top)+len(body)+len(end) <= height {
+		return false
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return false
+	}
+	defer restore()
+	in := bufio.NewReader(os.Stdin)
+
+	// room for lines of the body on a screen, under the header and above the prompt
+	room := height - len(top) - 1
+	if room < 1 {
+		room = 1
+	}
+
+	write := func(lines []string) {
+		for _, line := range lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+
+	write(top)
+	shown := 0
+	for next := room; ; {
+		if next > len(body) {
+			next = len(body)
+		}
+		write(body[shown:next])
+		shown = next
+		if shown == len(body) {
+			break
+		}
+
+		fmt.Fprint(out, reverseOn+morePrompt+ansiReset)
+		key, err := readKey(in)
+		fmt.Fprint(out, "\r"+clearLine)
+		if err != nil {
+			return true
+		}
+
+		switch {
+		case key.code == keyRune && key.r == ' ', key.code == keyPageDown:
+			write(top)
+			next = shown + room
+		case key.code == keyEnter, key.code == keyDown:
+			next = shown + 1
+		case key.code == keyRune && (key.r == 'q' || key.r == 'Q'), key.code == keyEscape, key.code == keyInterrupt:
+			return true
+		default:
+			next = shown
+		}
+	}
+	write(end)
+
+	return true
+}
+
+// captureLines returns the lines print prints, as they'd be printed to the table's output
+func captureLines(ct *Table, print func()) []string {
+
+	s := ct.captured(print)
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
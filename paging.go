@@ -0,0 +1,287 @@
+package ctable
+
+import (
+	"fmt"
+)
+
+/*
+Column paging for tables too wide for the screen.
+
+DisplayPaged() splits the columns into pages that each fit within a width, and prints the pages one after another,
+each with the key column (typically the row's name/id) repeated first so every page still reads on its own.
+
+With StackWhenWide set, Display() does much the same on its own whenever the table is wider than the terminal:
+the columns are broken into sub-tables that each fit, stacked one above the other (without the page banners).
+*/
+
+// DisplayPaged displays the table as a series of column pages no wider than width (MaxWidth or the terminal width when width is 0 or less),
+// with the column named keyColumn repeated at the start of every page ("" for no key column).
+func (ct *Table) DisplayPaged(showHeaders bool, width int, keyColumn string) {
+
+	key := -1
+	if keyColumn != "" {
+		if key = ct.columnIndex(keyColumn); key < 0 {
+			ct.fatal("DisplayPaged() key column " + keyColumn + " doesn't exist.")
+			return
+		}
+	}
+
+	if ct.displayEmpty() {
+		return
+	}
+
+	if width <= 0 {
+		width = ct.availableWidth()
+	} else {
+		width -= ct.decorationWidth()
+	}
+
+	lay := ct.computeLayout()
+	ct.recordWarnings(lay)
+	ct.lockWidths(lay)
+	ct.displayColumnPages(showHeaders, lay, ct.columnPages(lay, width, key), key, true)
+}
+
+// displayStacked is Display() for StackWhenWide tables too wide for the terminal
+func (ct *Table) displayStacked(showHeaders bool, lay layout) {
+
+	key := -1
+	if ct.StackKeyColumn != "" {
+		if key = ct.columnIndex(ct.StackKeyColumn); key < 0 {
+			ct.fatal("StackKeyColumn " + ct.StackKeyColumn + " doesn't exist.")
+			return
+		}
+	}
+
+	ct.displayColumnPages(showHeaders, lay, ct.columnPages(lay, ct.availableWidth(), key), key, false)
+}
+
+// displayColumnPages prints the pages of columns one after the other, with a banner saying which columns are on each page if banners is set
+func (ct *Table) displayColumnPages(showHeaders bool, lay layout, pages [][]int, key int, banners bool) {
+
+	for p, cols := range pages {
+
+		if p > 0 {
+			ct.printLine("")
+		}
+		if banners {
+			ct.printPageBanner(p, len(pages), cols, key)
+		}
+
+		// lines go inside the frame if there is one
+		f := ct.framer(lay, cols)
+		ct.printTop(showHeaders, lay, cols, f)
+		ct.printLines(lay, cols, f, false)
+		ct.printFooter(lay, cols, f)
+		ct.printBottom(f)
+	}
+
+	ct.printLegend(lay)
+}
+
+// framed puts s inside the frame f, if there is one
+func framed(f *framer, s string) string {
+	if f == nil {
+		return s
+	}
+	return f.content(s)
+}
+
+// printTop prints what goes above the rows of a page of columns - the top of the frame (or the title), and the header lines
+func (ct *Table) printTop(showHeaders bool, lay layout, cols []int, f *framer) {
+
+	if f != nil && !ct.Theme.OpenFrame {
+		ct.printLine(f.line(f.frame.top, ct.Title))
+	} else if ct.Title != "" {
+		ct.printLine(ct.Title)
+	}
+
+	if showHeaders {
+		headerStr, headerSeparator := ct.formatHeaderColumns(cols, lay)
+		ct.printLine(framed(f, headerStr))
+		if ct.Theme.HeaderSeparator != "" {
+			if f != nil {
+				headerSeparator = f.line(f.headerRule, "")
+			}
+			ct.printLine(headerSeparator)
+		}
+		if ct.Theme.SpaceHeader {
+			ct.printLine(framed(f, ""))
+		}
+	}
+}
+
+// printLines prints the display lines of a page of columns, with any rules between the rows -
+// continued is set when rows have already been printed above them (so the first row gets a rule too)
+func (ct *Table) printLines(lay layout, cols []int, f *framer, continued bool) {
+
+	rowSeparator := ""
+	if ct.Theme.RowSeparator != "" {
+		if f != nil {
+			rowSeparator = f.line(f.frame.rule, "")
+		} else {
+			rowSeparator = ct.formatRuleColumns(ct.separator(ct.Theme.RowSeparator), cols, lay)
+		}
+	}
+	rowStarts := ct.rowStartLines(lay)
+
+	// the lines are formatted a batch at a time (in parallel for big tables, see parallel.go)
+	for from := 0; from < lay.lines; from += formatBatchLines {
+		to := from + formatBatchLines
+		if to > lay.lines {
+			to = lay.lines
+		}
+		lines := ct.formatBatch(lay, cols, f, from, to)
+
+		for i := from; i < to; i++ {
+			l := lay.lineAt(i)
+			if rowSeparator != "" && (i > 0 || continued) && rowStarts[l] {
+				if merged := ct.mergedColumns(l, cols, lay); merged != nil {
+					ct.printLine(ct.mergedRule(f, merged, cols, lay))
+				} else {
+					ct.printLine(rowSeparator)
+				}
+			}
+			ct.printLine(lines[i-from])
+		}
+	}
+}
+
+// printBottom prints the bottom of the frame, if there is one
+func (ct *Table) printBottom(f *framer) {
+	if f != nil && !ct.Theme.OpenFrame {
+		ct.printLine(f.line(f.frame.bottom, ""))
+	}
+}
+
+func (ct *Table) printPageBanner(p int, pages int, cols []int, key int) {
+
+	first, last := cols[0], cols[len(cols)-1]
+	if key >= 0 && len(cols) > 1 {
+		first = cols[1]
+	}
+	if first == last {
+		ct.printLine(fmt.Sprintf("-- page %d of %d: column %d of %d --", p+1, pages, first+1, ct.ColumnCount))
+	} else {
+		ct.printLine(fmt.Sprintf("-- page %d of %d: columns %d-%d of %d --", p+1, pages, first+1, last+1, ct.ColumnCount))
+	}
+}
+
+// columnPages splits the columns into groups that each fit in width when displayed together,
+// with the key column (if any, -1 for none) at the start of every group. Every group gets at least one column
+// besides the key column, even if that doesn't fit.
+func (ct *Table) columnPages(lay layout, width int, key int) [][]int {
+
+	pages := [][]int{}
+	var page []int
+	pageWidth := 0
+
+	newPage := func() {
+		page = []int{}
+		pageWidth = 0
+		if key >= 0 {
+			page = append(page, key)
+			pageWidth = lay.widths[key]
+		}
+	}
+
+	newPage()
+	for _, c := range lay.columns() {
+		if c == key {
+			continue
+		}
+
+		// plus the gap between columns
+		added := lay.widths[c]
+		if len(page) > 0 {
+			added += textWidth(lay.gap)
+		}
+
+		hasOthers := len(page) > 1 || len(page) == 1 && key < 0
+		if hasOthers && pageWidth+added > width {
+			pages = append(pages, page)
+			newPage()
+			added = lay.widths[c]
+			if len(page) > 0 {
+				added += textWidth(lay.gap)
+			}
+		}
+
+		page = append(page, c)
+		pageWidth += added
+	}
+
+	if len(page) > 1 || len(page) == 1 && key < 0 {
+		pages = append(pages, page)
+	}
+
+	return pages
+}
+
+// formatLineColumns is formatLine() for just the columns in cols
+func (ct *Table) formatLineColumns(l int, cols []int, lay layout) string {
+
+	if r, ok := isAnnotationLine(l); ok {
+		return ct.annotationText(r)
+	}
+
+	if isSeparatorLine(l) {
+		width := 0
+		for i, c := range cols {
+			if i > 0 {
+				width += textWidth(lay.gap)
+			}
+			width += lay.widths[c]
+		}
+		return ct.separatorText(l, width)
+	}
+
+	if summary, ok := lay.summaries[l]; ok {
+		return lay.styleLine(l, lay.prefixes[l]+summary)
+	}
+
+	rowStr := ""
+	for i, c := range cols {
+		if i == 0 {
+			rowStr += ct.formatField(l, c, lay)
+		} else {
+			rowStr += lay.gap + ct.formatField(l, c, lay)
+		}
+	}
+
+	return lay.styleLine(l, ct.trimmedLine(l, rowStr, lay))
+}
+
+// formatRuleColumns returns a line of rule repeated across each of the columns in cols, with the gaps between columns as in the header
+func (ct *Table) formatRuleColumns(rule string, cols []int, lay layout) string {
+
+	line := ""
+	for i, c := range cols {
+		if i > 0 {
+			line += lay.headerGap
+		}
+		line += repeatToWidth(rule, lay.widths[c])
+	}
+
+	return colorize(line, ct.Theme.BorderColor)
+}
+
+// formatHeaderColumns is formatHeader() for just the columns in cols
+func (ct *Table) formatHeaderColumns(cols []int, lay layout) (string, string) {
+
+	headerStr := ""
+	headerSeparator := ""
+
+	for i, c := range cols {
+		name, separator := ct.formatHeaderField(c, lay)
+		if i == 0 {
+			headerStr += name
+			headerSeparator += separator
+		} else {
+			headerStr += lay.headerGap + name
+			headerSeparator += lay.headerGap + separator
+		}
+	}
+
+	return Style{Color: ct.Theme.HeaderColor}.apply(ct.trimmedLine(-1, headerStr, lay)), colorize(headerSeparator, ct.Theme.BorderColor)
+}
@@ -0,0 +1,58 @@
+package ctable
+
+import (
+	"runtime"
+	"sync"
+)
+
+/*
+Parallel formatting.
+
+Formatting the lines (truncation, padding, justification, styling) is most of the work of printing a big table. For
+tables of parallelLines display lines or more the lines are formatted a batch at a time, each batch split across a
+goroutine per CPU, and printed in order once the batch is done - so output starts straight away and memory stays at a
+batch's worth of lines, however many rows there are.
+*/
+
+// tables with fewer display lines than this are formatted on the one goroutine, the others aren't worth starting
+// (a var so the benchmarks can compare the two)
+var parallelLines = 20000
+
+// number of display lines formatted at a time
+const formatBatchLines = 8192
+
+// formatBatch returns the display lines at positions from up to (not including) to, formatted for the columns in cols
+// (inside the frame f, if there is one)
+func (ct *Table) formatBatch(lay layout, cols []int, f *framer, from int, to int) []string {
+
+	lines := make([]string, to-from)
+	format := func(start int, end int) {
+		for i := start; i < end; i++ {
+			lines[i-from] = framed(f, ct.formatLineColumns(lay.lineAt(i), cols, lay))
+		}
+	}
+
+	// lazy values still to be worked out would be written to the table while it's read, those tables stay on one goroutine
+	workers := runtime.GOMAXPROCS(0)
+	if lay.lines < parallelLines || workers < 2 || ct.lazyCells != nil {
+		format(from, to)
+		return lines
+	}
+
+	var wg sync.WaitGroup
+	share := (to - from + workers - 1) / workers
+	for start := from; start < to; start += share {
+		end := start + share
+		if end > to {
+			end = to
+		}
+		wg.Add(1)
+		go func(start int, end int) {
+			defer wg.Done()
+			format(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+
+	return lines
+}
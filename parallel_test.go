@@ -0,0 +1,47 @@
+package ctable
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// benchmarkTable returns a table of rows rows, a mix of text and numbers with some values cut short
+func benchmarkTable(rows int) *Table {
+
+	ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("Region", 0), NewColumn("Size", 0), NewColumn("Description", 24)})
+	ct.Columns[2].Justification = "right"
+
+	for i := 0; i < rows; i++ {
+		ct.AddRow("node-"+strconv.Itoa(i), "eu-west-"+strconv.Itoa(i%3), i*37%100000, "a node in the benchmark table, number "+strconv.Itoa(i))
+	}
+
+	return &ct
+}
+
+func BenchmarkDisplay(b *testing.B) {
+
+	for _, rows := range []int{100000, 500000} {
+		ct := benchmarkTable(rows)
+		ct.output = io.Discard
+
+		for _, mode := range []struct {
+			name      string
+			threshold int
+		}{
+			{"serial", math.MaxInt},
+			{"parallel", 0},
+		} {
+			b.Run(fmt.Sprintf("%d/%s", rows, mode.name), func(b *testing.B) {
+				defer func(threshold int) { parallelLines = threshold }(parallelLines)
+				parallelLines = mode.threshold
+
+				for i := 0; i < b.N; i++ {
+					ct.Display(true)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Package pdftable lays a ctable.Table out into a paginated PDF, for tools that have to produce printable, audit-ready
+reports. Every page repeats the table's title and column headers, and is numbered "Page n of m" at the bottom.
+
+It's a separate package so programs that don't need PDFs don't carry the code, and it has no dependencies beyond the
+standard library - the table is set in Courier, one of the fonts every PDF reader has built in, at a size that fits the
+widest line on the page.
+
+Example:
+
+	f, _ := os.Create("report.pdf")
+	defer f.Close()
+	err := pdftable.Encode(f, &ct, nil)
+
+The built in fonts only cover Latin-1, so box drawing characters (frames, separators, tree guides) are drawn as their
+ASCII stand-ins and anything else outside Latin-1 as "?". ANSI colors and styles are dropped.
+*/
+package pdftable
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/dcopenhaver/ctable"
+)
+
+type Options struct {
+	ShowHeaders bool
+	PageWidth   float64 // in points (1/72 inch), A4 (595 x 842) when 0
+	PageHeight  float64
+	Landscape   bool    // swap the width and height
+	Margin      float64 // in points, on all four sides
+	FontSize    float64 // in points, the largest size used - smaller when that's needed to fit the widest line
+}
+
+// DefaultOptions is A4 portrait with headers, 1/2 inch margins, and text up to 9 points.
+func DefaultOptions() *Options {
+	return &Options{
+		ShowHeaders: true,
+		PageWidth:   595,
+		PageHeight:  842,
+		Margin:      36,
+		FontSize:    9,
+	}
+}
+
+const (
+	charWidth   = 0.6 // Courier's advance width, in ems
+	lineSpacing = 1.2 // baseline to baseline, in ems
+	minFontSize = 4   // wider tables than fit at this size run off the right of the page
+)
+
+// Encode lays the table out and writes it to w as a PDF, nil opts for DefaultOptions().
+func Encode(w io.Writer, ct *ctable.Table, opts *Options) error {
+
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	o := *opts
+	if o.PageWidth <= 0 || o.PageHeight <= 0 {
+		o.PageWidth, o.PageHeight = 595, 842
+	}
+	if o.Landscape {
+		o.PageWidth, o.PageHeight = o.PageHeight, o.PageWidth
+	}
+	if o.FontSize <= 0 {
+		o.FontSize = 9
+	}
+
+	header, rows := tableLines(ct, o.ShowHeaders)
+	if ct.Title != "" {
+		header = append([]string{plain(ct.Title), ""}, header...)
+	}
+
+	// the font size that fits the widest line across the page
+	widest := 0
+	for _, line := range append(header, rows...) {
+		if n := len([]rune(line)); n > widest {
+			widest = n
+		}
+	}
+	size := o.FontSize
+	if widest > 0 {
+		if fit := (o.PageWidth - 2*o.Margin) / (float64(widest) * charWidth); fit < size {
+			size = fit
+		}
+	}
+	if size < minFontSize {
+		size = minFontSize
+	}
+	leading := size * lineSpacing
+
+	// lines per page under the header, leaving a line and a gap at the bottom for the page number
+	perPage := int((o.PageHeight-2*o.Margin)/leading) - len(header) - 2
+	if perPage < 1 {
+		perPage = 1
+	}
+	pages := [][]string{}
+	for start := 0; start < len(rows) || start == 0; start += perPage {
+		end := start + perPage
+		if end > len(rows) {
+			end = len(rows)
+		}
+		pages = append(pages, rows[start:end])
+	}
+
+	doc := newDocument()
+	for p, lines := range pages {
+		// starting a line above the first, as ' moves down a line before showing each one
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %.2f Tf\n%.2f TL\n%.2f %.2f Td\n", size, leading, o.Margin, o.PageHeight-o.Margin-size+leading)
+		for _, line := range append(append([]string{}, header...), lines...) {
+			fmt.Fprintf(&content, "(%s) '\n", pdfString(line))
+		}
+		fmt.Fprintf(&content, "ET\nBT\n/F1 %.2f Tf\n%.2f %.2f Td\n(%s) Tj\nET\n", size, o.Margin, o.Margin, pdfString(fmt.Sprintf("Page %d of %d", p+1, len(pages))))
+		doc.addPage(content.Bytes())
+	}
+
+	return doc.write(w, o.PageWidth, o.PageHeight)
+}
+
+// tableLines returns the header lines and the lines of the rows as Display() prints them, without ANSI codes
+func tableLines(ct *ctable.Table, showHeaders bool) (header []string, rows []string) {
+
+	ct.ResetWindow()
+
+	if showHeaders {
+		name, separator := ct.RenderWindowHeader()
+		header = append(header, plain(name))
+		if ct.Theme.HeaderSeparator != "" {
+			header = append(header, plain(separator))
+		}
+		if ct.Theme.SpaceHeader {
+			header = append(header, "")
+		}
+	}
+
+	for _, line := range ct.RenderWindow(0, ct.Layout().Lines) {
+		rows = append(rows, plain(line))
+	}
+
+	return header, rows
+}
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*[@-~]")
+
+// box drawing (and other decoration) characters, as drawn in Latin-1
+var latin1Replacer = strings.NewReplacer(
+	"─", "-", "━", "-", "═", "=", "│", "|", "┃", "|", "║", "|",
+	"┼", "+", "╋", "+", "╬", "+", "├", "|", "└", "`", "▶", ">", "…", "...",
+)
+
+// plain returns line without ANSI codes, and with only characters the built in fonts have
+func plain(line string) string {
+	return latin1Replacer.Replace(ansiSequence.ReplaceAllString(line, ""))
+}
+
+// pdfString returns s as the contents of a PDF string literal in the font's (Latin-1) encoding
+func pdfString(s string) string {
+
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20 || r > 0xff:
+			sb.WriteByte('?')
+		case r > 0x7e:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// document collects the pages' content streams and writes out the PDF objects around them
+type document struct {
+	pages [][]byte
+}
+
+func newDocument() *document {
+	return &document{}
+}
+
+func (d *document) addPage(content []byte) {
+	d.pages = append(d.pages, content)
+}
+
+// write writes the PDF - catalog, page tree, font, then a page object and content stream per page, and the cross reference table
+func (d *document) write(w io.Writer, width float64, height float64) error {
+
+	bw := bufio.NewWriter(w)
+	offset := 0
+	offsets := []int{}
+
+	out := func(s string) {
+		n, _ := bw.WriteString(s)
+		offset += n
+	}
+	object := func(body string) {
+		offsets = append(offsets, offset)
+		out(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", len(offsets), body))
+	}
+
+	out("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// objects 1-3, then objects 4 and 5 are the first page and its content, 6 and 7 the second, and so on
+	kids := []string{}
+	for p := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*p))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for p, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			width, height, 5+2*p))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := offset
+	out(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1))
+	for _, o := range offsets {
+		out(fmt.Sprintf("%010d 00000 n \n", o))
+	}
+	out(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref))
+
+	return bw.Flush()
+}
@@ -0,0 +1,145 @@
+package ctable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+Machine readable output when piped.
+
+With PipedFormat set, Display() checks where its output is going - a terminal gets the table as usual, anything else
+(a pipe, a file) gets the data in a format scripts can read without having to parse padded columns:
+
+	"tsv"    tab separated values, the column names first (when showing headers), then a line per row.
+	         Tabs, newlines (multiline values), and backslashes in values are written as \t, \n, and \\.
+	"json"   an array with an object per row, keyed by column name, multiline values as arrays of strings.
+	"plain"  fields separated by a single space, unpadded, for column -t, awk, cut -d' ', sort -k and friends.
+	         Values that are empty or contain spaces, quotes, backslashes, or newlines are written as Go (and
+	         near enough shell) double quoted strings, e.g. "two words" and "line 1\nline 2".
+
+WritePlain() writes the "plain" format regardless of where it's going, and RenderJSON() the "json" format, e.g. for a
+tool's --json flag. MarshalJSON() makes a *Table marshal to the same JSON with encoding/json.
+
+Values are written without any ANSI styling, in the sorted order (see SortBy(), the order rows were added if the
+table isn't sorted), including rows in collapsed groups.
+*/
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// writeMachineReadable writes the table's data in PipedFormat
+func (ct *Table) writeMachineReadable(showHeaders bool) {
+
+	ct.clearProgress()
+	w := bufio.NewWriter(ct.writer())
+	defer w.Flush()
+
+	switch strings.ToLower(ct.PipedFormat) {
+	case "plain":
+		ct.writeFields(w, showHeaders, "plain", " ")
+		return
+	case "json":
+		ct.writeJSON(w)
+		return
+	}
+
+	ct.writeFields(w, showHeaders, "tsv", "\t")
+}
+
+// WritePlain writes the table's data to w as unpadded fields separated by single spaces (PipedFormat "plain", see pipe.go).
+func (ct *Table) WritePlain(w io.Writer, showHeaders bool) error {
+	bw := bufio.NewWriter(w)
+	ct.writeFields(bw, showHeaders, "plain", " ")
+	return bw.Flush()
+}
+
+// RenderJSON writes the table's data to w as a JSON array with an object per row, keyed by column name (PipedFormat "json", see pipe.go).
+func (ct *Table) RenderJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	ct.writeJSON(bw)
+	return bw.Flush()
+}
+
+// MarshalJSON returns the table's data as RenderJSON() writes it.
+func (ct *Table) MarshalJSON() ([]byte, error) {
+
+	var buf bytes.Buffer
+	if err := ct.RenderJSON(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeJSON writes the rows as a JSON array of objects, one per line
+func (ct *Table) writeJSON(w *bufio.Writer) {
+
+	w.WriteString("[")
+	for pos := 0; pos < ct.RowCount; pos++ {
+		if pos > 0 {
+			w.WriteString(",")
+		}
+		w.WriteString("\n  " + ct.rowJSON(ct.OriginalIndex(pos)))
+	}
+	w.WriteString("\n]\n")
+}
+
+// writeFields writes a line per row (after a line of column names when showing headers), fields escaped for format
+// and separated by separator
+func (ct *Table) writeFields(w *bufio.Writer, showHeaders bool, format string, separator string) {
+
+	if showHeaders {
+		names := make([]string, ct.ColumnCount)
+		for c, col := range ct.Columns {
+			names[c] = ct.escape(format, col.Name)
+		}
+		w.WriteString(strings.Join(names, separator) + "\n")
+	}
+	for pos := 0; pos < ct.RowCount; pos++ {
+		row := ct.OutputRow(ct.OriginalIndex(pos))
+		for c := range row {
+			row[c] = ct.escape(format, stripANSI(row[c]))
+		}
+		w.WriteString(strings.Join(row, separator) + "\n")
+	}
+}
+
+// plainField quotes a value for the "plain" format when it has to be, so it stays a single field
+func plainField(s string) string {
+
+	if s != "" && !strings.ContainsAny(s, " \t\n\r\"'\\") {
+		return s
+	}
+
+	return strconv.Quote(s)
+}
+
+// rowJSON returns row r as a JSON object, keyed by column name in column order
+func (ct *Table) rowJSON(r int) string {
+
+	var sb strings.Builder
+	sb.WriteString("{")
+
+	for c, value := range ct.OutputRow(r) {
+		if c > 0 {
+			sb.WriteString(", ")
+		}
+		key, _ := json.Marshal(ct.Columns[c].Name)
+		sb.Write(key)
+		sb.WriteString(": ")
+
+		var v interface{} = stripANSI(value)
+		if strings.Contains(value, "\n") {
+			v = strings.Split(stripANSI(value), "\n")
+		}
+		data, _ := json.Marshal(v)
+		sb.Write(data)
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+/*
+Package pngtable draws a ctable.Table to a PNG image, laid out exactly as Display() prints it in a monospace font,
+for posting reports to chat tools and dashboards that don't keep text columns lined up.
+
+It's a separate package so the font dependency (golang.org/x/image) is only pulled in by programs that use it.
+
+Example:
+
+	f, _ := os.Create("report.png")
+	defer f.Close()
+	err := pngtable.Encode(f, &ct, nil)
+
+ANSI colors in the table (HeaderColor, ErrorColor, Style etc.) are drawn in the matching color, other attributes are ignored.
+*/
+package pngtable
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dcopenhaver/ctable"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type Options struct {
+	ShowHeaders bool
+	Margin      int // pixels around the table
+	Background  color.Color
+	Foreground  color.Color // text color when the table doesn't say otherwise
+}
+
+// DefaultOptions is light text on a dark background, with headers.
+func DefaultOptions() *Options {
+	return &Options{
+		ShowHeaders: true,
+		Margin:      8,
+		Background:  color.RGBA{0x1e, 0x1e, 0x1e, 0xff},
+		Foreground:  color.RGBA{0xd4, 0xd4, 0xd4, 0xff},
+	}
+}
+
+// Encode draws the table and writes it to w as a PNG, nil opts for DefaultOptions().
+func Encode(w io.Writer, ct *ctable.Table, opts *Options) error {
+	return png.Encode(w, Draw(ct, opts))
+}
+
+// Draw draws the table to a new image, nil opts for DefaultOptions().
+func Draw(ct *ctable.Table, opts *Options) *image.RGBA {
+
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	face := basicfont.Face7x13
+	lines := tableLines(ct, opts.ShowHeaders)
+
+	columns := 0
+	for _, line := range lines {
+		if n := len(parseLine(line, opts.Foreground)); n > columns {
+			columns = n
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, columns*face.Advance+2*opts.Margin, len(lines)*face.Height+2*opts.Margin))
+	draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+
+	d := font.Drawer{Dst: img, Face: face}
+	for i, line := range lines {
+		y := opts.Margin + i*face.Height + face.Ascent
+		for x, g := range parseLine(line, opts.Foreground) {
+			d.Src = image.NewUniform(g.color)
+			d.Dot = fixed.P(opts.Margin+x*face.Advance, y)
+			d.DrawString(string(g.r))
+		}
+	}
+
+	return img
+}
+
+// tableLines returns the lines Display() prints, ANSI codes and all
+func tableLines(ct *ctable.Table, showHeaders bool) []string {
+
+	// (drawn as it's displayed on a terminal, whatever PipedFormat says for output that isn't one)
+	defer func(format string) { ct.PipedFormat = format }(ct.PipedFormat)
+	ct.PipedFormat = ""
+
+	var sb strings.Builder
+	ct.Fprint(ctable.ColorWriter(&sb), showHeaders)
+
+	return strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+}
+
+type glyph struct {
+	r     rune
+	color color.Color
+}
+
+// parseLine splits line into the characters shown and their colors, following ANSI SGR color codes
+func parseLine(line string, fg color.Color) []glyph {
+
+	glyphs := []glyph{}
+	current := fg
+
+	for i := 0; i < len(line); {
+		if strings.HasPrefix(line[i:], "\x1b[") {
+			end := strings.IndexFunc(line[i+2:], func(r rune) bool { return r >= '@' && r <= '~' })
+			if end < 0 {
+				break
+			}
+			if line[i+2+end] == 'm' {
+				current = sgrColor(line[i+2:i+2+end], current, fg)
+			}
+			i += 2 + end + 1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		glyphs = append(glyphs, glyph{r: r, color: current})
+		i += size
+	}
+
+	return glyphs
+}
+
+// the standard 8 colors and their bright versions
+var palette = []color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0xcd, 0x31, 0x31, 0xff}, {0x0d, 0xbc, 0x79, 0xff}, {0xe5, 0xe5, 0x10, 0xff},
+	{0x24, 0x72, 0xc8, 0xff}, {0xbc, 0x3f, 0xbc, 0xff}, {0x11, 0xa8, 0xcd, 0xff}, {0xe5, 0xe5, 0xe5, 0xff},
+	{0x66, 0x66, 0x66, 0xff}, {0xf1, 0x4c, 0x4c, 0xff}, {0x23, 0xd1, 0x8b, 0xff}, {0xf5, 0xf5, 0x43, 0xff},
+	{0x3b, 0x8e, 0xea, 0xff}, {0xd6, 0x70, 0xd6, 0xff}, {0x29, 0xb8, 0xdb, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// sgrColor returns the text color after the SGR parameters params, starting from current
+func sgrColor(params string, current color.Color, fg color.Color) color.Color {
+
+	if params == "" {
+		return fg
+	}
+
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		n, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case n == 38 || n == 48:
+			// 256 color and RGB colors aren't supported, skip their arguments
+			if i+1 < len(codes) && codes[i+1] == "5" {
+				i += 2
+			} else if i+1 < len(codes) && codes[i+1] == "2" {
+				i += 4
+			}
+		case n == 0 || n == 39:
+			current = fg
+		case n >= 30 && n <= 37:
+			current = palette[n-30]
+		case n >= 90 && n <= 97:
+			current = palette[n-90+8]
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,40 @@
+package pngtable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dcopenhaver/ctable"
+)
+
+func TestTableLines(t *testing.T) {
+
+	ct := ctable.NewTable([]ctable.Column{ctable.NewColumn("Name", 0), ctable.NewColumn("Size", 0)})
+	ct.Theme.Frame = "rounded"
+	ct.Title = "Files"
+	ct.AddRow("a.txt", 12)
+	ct.AddRow("b.txt", 3)
+
+	want, err := ct.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(tableLines(&ct, true), "\n") + "\n"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDraw(t *testing.T) {
+
+	ct := ctable.NewTable([]ctable.Column{ctable.NewColumn("Name", 0)})
+	ct.AddRow("a.txt")
+
+	opts := DefaultOptions()
+	img := Draw(&ct, opts)
+
+	// 5 characters wide (the separator line) by 3 lines, in the margin
+	if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w != 5*7+2*opts.Margin || h != 3*13+2*opts.Margin {
+		t.Errorf("got a %dx%d image, want %dx%d", w, h, 5*7+2*opts.Margin, 3*13+2*opts.Margin)
+	}
+}
@@ -0,0 +1,57 @@
+package ctable
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+/*
+Progress while loading.
+
+Filling a table from a slow source (a SQL query, a big CSV file, a paginated API) can take a while with nothing on
+screen. With ShowProgress set a transient status line, "loaded 12000 rows…", is kept up to date on stderr as rows are
+added, and erased before the table is printed. It only appears once loading has taken longer than a moment
+(so quick loads don't flash it), and only when stderr is a terminal.
+*/
+
+// how long loading goes on before the progress line appears, and how often it's updated after that
+const progressInterval = 250 * time.Millisecond
+
+// showProgress updates the progress line for the row just added, if it's time to
+func (ct *Table) showProgress() {
+
+	if !ct.ShowProgress {
+		return
+	}
+
+	now := time.Now()
+	if ct.progressSince.IsZero() {
+		ct.progressSince = now
+		return
+	}
+	if now.Sub(ct.progressSince) < progressInterval || !isTerminal(os.Stderr) {
+		return
+	}
+	ct.progressSince = now
+
+	ellipsis := "…"
+	if ct.ASCII {
+		ellipsis = "..."
+	}
+	fmt.Fprintf(os.Stderr, "\r%sloaded %d rows%s", clearLine, ct.RowCount, ellipsis)
+	ct.progressShown = true
+}
+
+// clearProgress erases the progress line, if it's showing, and starts the timing over for the next load
+func (ct *Table) clearProgress() {
+
+	ct.progressSince = time.Time{}
+
+	if !ct.progressShown {
+		return
+	}
+
+	fmt.Fprint(os.Stderr, "\r"+clearLine)
+	ct.progressShown = false
+}
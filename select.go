@@ -0,0 +1,377 @@
+package ctable
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/*
+Row selection.
+
+SelectRow() shows the table with a row number in front of each row and lets the user pick one.
+On a terminal the current row is highlighted and moved with the arrow keys (or by typing its number), enter picks it.
+When stdin/stdout aren't a terminal it falls back to printing the numbered table and reading the number as a line of input.
+
+SelectRows() is the multi-select version, a [ ]/[x] checkbox column goes in front of the rows and space toggles the current row
+('a' toggles them all), enter accepts the checked set. The non terminal fallback reads a list of numbers (e.g. "1,4 7").
+*/
+
+// ErrSelectionCanceled is returned when the user backs out of a selection (esc, q, ctrl-c, or end of input).
+var ErrSelectionCanceled = errors.New("CONSOLETABLE: selection canceled")
+
+// SelectRow displays the table with row numbers under prompt and returns the (zero based) index of the row the user picks.
+func (ct *Table) SelectRow(prompt string) (int, error) {
+
+	if ct.RowCount == 0 {
+		return -1, errors.New("CONSOLETABLE: there are no rows to select from")
+	}
+
+	s := newSelector(ct, prompt)
+
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return s.readLine(os.Stdin, os.Stdout)
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return -1, err
+	}
+	defer restore()
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor + clearScreen)
+
+	return s.run(bufio.NewReader(os.Stdin), os.Stdout)
+}
+
+// SelectRows displays the table with row numbers and checkboxes under prompt and returns the (zero based, in table order) indexes of the rows the user checks.
+func (ct *Table) SelectRows(prompt string) ([]int, error) {
+
+	if ct.RowCount == 0 {
+		return nil, errors.New("CONSOLETABLE: there are no rows to select from")
+	}
+
+	s := newSelector(ct, prompt)
+	s.checked = make([]bool, ct.RowCount)
+
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return s.readLines(os.Stdin, os.Stdout)
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor + clearScreen)
+
+	if _, err := s.run(bufio.NewReader(os.Stdin), os.Stdout); err != nil {
+		return nil, err
+	}
+
+	return s.checkedRows(), nil
+}
+
+type selector struct {
+	ct     *Table
+	lay    layout
+	prompt string
+
+	// rows are listed (and numbered) in display order, so positions here are display positions rather than row indexes
+	rows        []int // row shown in each position
+	lines       []int // display lines in the order shown
+	linePos     []int // position of the row each entry in lines belongs to
+	firstLine   []int // index into lines of the first line of the row in each position
+	numberWidth int   // width of the row number column
+	cursor      int   // position of the highlighted row
+	typed       string
+	top         int    // index into lines of the first line on screen
+	checked     []bool // checked rows (by row index), nil when selecting a single row
+}
+
+func newSelector(ct *Table, prompt string) *selector {
+
+	s := &selector{
+		ct:     ct,
+		lay:    ct.computeLayout(),
+		prompt: prompt,
+	}
+
+	for pos := 0; pos < s.lay.rows; pos++ {
+		r := s.lay.rowAt(pos)
+		s.rows = append(s.rows, r)
+		s.firstLine = append(s.firstLine, len(s.lines))
+		s.lines = ct.appendRowLines(s.lines, s.lay, r)
+		for len(s.linePos) < len(s.lines) {
+			s.linePos = append(s.linePos, pos)
+		}
+	}
+	s.numberWidth = len(strconv.Itoa(len(s.rows)))
+
+	return s
+}
+
+// rowLines returns the range (into lines) of the lines of the row in position pos
+func (s *selector) rowLines(pos int) (int, int) {
+	if pos+1 < len(s.firstLine) {
+		return s.firstLine[pos], s.firstLine[pos+1]
+	}
+	return s.firstLine[pos], len(s.lines)
+}
+
+// header returns the table header lines with room left for the row numbers (and checkboxes)
+func (s *selector) header() (string, string) {
+
+	indent := s.numberWidth + 1
+	if s.checked != nil {
+		indent += 4
+	}
+
+	header, separator := s.ct.formatHeader(s.lay)
+	return strings.Repeat(" ", indent) + header, strings.Repeat(" ", indent) + separator
+}
+
+// numberedLine returns lines[i] with the row number (and checkbox) in front, only the first line of a row gets them
+func (s *selector) numberedLine(i int) string {
+
+	pos := s.linePos[i]
+	r := s.rows[pos]
+	first := s.firstLine[pos] == i
+
+	checkbox := ""
+	if s.checked != nil {
+		switch {
+		case !first:
+			checkbox = "    "
+		case s.checked[r]:
+			checkbox = "[x] "
+		default:
+			checkbox = "[ ] "
+		}
+	}
+
+	number := ""
+	if first {
+		number = strconv.Itoa(pos + 1)
+	}
+
+	return checkbox + padText(number, s.numberWidth, "right") + " " + s.ct.formatLine(s.lines[i], s.lay)
+}
+
+// checkedRows returns the indexes of the checked rows
+func (s *selector) checkedRows() []int {
+
+	rows := []int{}
+	for r, checked := range s.checked {
+		if checked {
+			rows = append(rows, r)
+		}
+	}
+
+	return rows
+}
+
+// toggleAll checks every row, or unchecks them all if they're already all checked
+func (s *selector) toggleAll() {
+
+	all := len(s.checkedRows()) == len(s.checked)
+	for r := range s.checked {
+		s.checked[r] = !all
+	}
+}
+
+// readLine is the non terminal fallback, print the numbered table and read the row number from in
+func (s *selector) readLine(in io.Reader, out io.Writer) (int, error) {
+
+	header, separator := s.header()
+	fmt.Fprintln(out, header)
+	fmt.Fprintln(out, separator)
+	for i := range s.lines {
+		fmt.Fprintln(out, s.numberedLine(i))
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "%s [1-%d]: ", s.prompt, len(s.rows))
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return -1, err
+			}
+			return -1, ErrSelectionCanceled
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+		if err == nil && n >= 1 && n <= len(s.rows) {
+			return s.rows[n-1], nil
+		}
+		fmt.Fprintf(out, "Please enter a number from 1 to %d.\n", len(s.rows))
+	}
+}
+
+// readLines is the non terminal fallback for multi-select, print the numbered table and read a list of row numbers from in
+func (s *selector) readLines(in io.Reader, out io.Writer) ([]int, error) {
+
+	header, separator := s.header()
+	fmt.Fprintln(out, header)
+	fmt.Fprintln(out, separator)
+	for i := range s.lines {
+		fmt.Fprintln(out, s.numberedLine(i))
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "%s [numbers 1-%d, separated by spaces or commas]: ", s.prompt, len(s.rows))
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, ErrSelectionCanceled
+		}
+
+		valid := true
+		for r := range s.checked {
+			s.checked[r] = false
+		}
+		for _, field := range strings.FieldsFunc(scanner.Text(), func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			n, err := strconv.Atoi(field)
+			if err != nil || n < 1 || n > len(s.rows) {
+				valid = false
+				break
+			}
+			s.checked[s.rows[n-1]] = true
+		}
+		if valid {
+			return s.checkedRows(), nil
+		}
+		fmt.Fprintf(out, "Please enter numbers from 1 to %d.\n", len(s.rows))
+	}
+}
+
+// move puts the cursor on the row in position pos (kept within the table) and scrolls so all of its lines are on screen
+func (s *selector) move(pos int, height int) {
+
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(s.rows) {
+		pos = len(s.rows) - 1
+	}
+	s.cursor = pos
+
+	page := s.pageHeight(height)
+	first, end := s.rowLines(pos)
+	if first < s.top {
+		s.top = first
+	}
+	if end > s.top+page {
+		s.top = end - page
+	}
+}
+
+// pageHeight is the number of table lines that fit on screen with the prompt and header
+func (s *selector) pageHeight(height int) int {
+	if height < 5 {
+		return 1
+	}
+	return height - 4
+}
+
+func (s *selector) draw(out io.Writer, width int, height int) {
+
+	var sb strings.Builder
+	sb.WriteString(clearScreen)
+
+	header, separator := s.header()
+	sb.WriteString(truncateText(header, width) + "\n")
+	sb.WriteString(truncateText(separator, width) + "\n")
+
+	for i := s.top; i < s.top+s.pageHeight(height) && i < len(s.lines); i++ {
+		line := truncateText(s.numberedLine(i), width)
+		if s.linePos[i] == s.cursor {
+			line = reverseOn + line + ansiReset
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	help := " (arrows or number, enter to select, esc to cancel): "
+	if s.checked != nil {
+		help = fmt.Sprintf(" (%d checked - arrows or number to move, space to check, a for all, enter to accept, esc to cancel): ", len(s.checkedRows()))
+	}
+	sb.WriteString("\n" + truncateText(s.prompt+help+s.typed, width))
+	io.WriteString(out, sb.String())
+}
+
+func (s *selector) run(in *bufio.Reader, out *os.File) (int, error) {
+
+	for {
+		width, height, err := terminalSize(out)
+		if err != nil {
+			return -1, err
+		}
+
+		s.move(s.cursor, height)
+		s.draw(out, width, height)
+
+		key, err := readKey(in)
+		if err != nil {
+			return -1, err
+		}
+
+		switch {
+		case key.code == keyEnter:
+			return s.rows[s.cursor], nil
+		case key.code == keyInterrupt, key.code == keyEscape, key.code == keyRune && key.r == 'q':
+			return -1, ErrSelectionCanceled
+		case key.code == keyUp, key.code == keyRune && key.r == 'k':
+			s.typed = ""
+			s.move(s.cursor-1, height)
+		case key.code == keyDown, key.code == keyRune && key.r == 'j':
+			s.typed = ""
+			s.move(s.cursor+1, height)
+		case key.code == keyPageUp:
+			s.typed = ""
+			s.move(s.cursor-s.pageHeight(height), height)
+		case key.code == keyPageDown:
+			s.typed = ""
+			s.move(s.cursor+s.pageHeight(height), height)
+		case key.code == keyHome:
+			s.typed = ""
+			s.move(0, height)
+		case key.code == keyEnd:
+			s.typed = ""
+			s.move(len(s.rows)-1, height)
+		case key.code == keyRune && key.r == ' ' && s.checked != nil:
+			s.checked[s.rows[s.cursor]] = !s.checked[s.rows[s.cursor]]
+		case key.code == keyRune && key.r == 'a' && s.checked != nil:
+			s.toggleAll()
+		case key.code == keyBackspace:
+			if len(s.typed) > 0 {
+				s.typed = s.typed[:len(s.typed)-1]
+			}
+		case key.code == keyRune && key.r >= '0' && key.r <= '9':
+			s.typeDigit(key.r, height)
+		}
+	}
+}
+
+// typeDigit adds a digit to the row number being typed and moves the cursor to that row,
+// a number that runs past the last row starts over with just the new digit
+func (s *selector) typeDigit(digit rune, height int) {
+
+	s.typed += string(digit)
+	n, _ := strconv.Atoi(s.typed)
+	if n > len(s.rows) {
+		s.typed = string(digit)
+		n, _ = strconv.Atoi(s.typed)
+	}
+
+	if n >= 1 && n <= len(s.rows) {
+		s.move(n-1, height)
+	}
+}
@@ -0,0 +1,168 @@
+package ctable
+
+import (
+	"sort"
+)
+
+/*
+Sorting.
+
+SortRows() puts the rows in order for display (and for the exports - TSV, JSON, CSV etc.), without moving them in the
+table: Row(i), RawValue(), AddChildRow() etc. still take the index of the row in the order it was added, and
+OriginalIndex() maps a position in the sorted order back to it.
+
+Sorting is stable - rows that compare equal keep the order they were in - so sorting by one key and then another sorts
+by the second key first and the first within it, like clicking column headings one after the other in a spreadsheet:
+
+	ct.SortRows(func(a, b []string) bool { return a[1] < b[1] })   // by name
+	ct.SortRows(func(a, b []string) bool { return a[0] < b[0] })   // by region, names in order within each region
+
+Rows added after sorting go after the sorted ones, in the order they're added, until the next sort. Child rows
+(AddChildRow()) stay under their parents, sorted among their siblings.
+
+With SortIndicators set, the column the rows are sorted by is marked in the header with ▲ (ascending) or ▼ (descending),
+^ and v with ASCII set - the column is widened to make room if it has to be. SortRows() can't know which column its
+less func sorts by, SetSortIndicator() says (it's also the way to mark a table whose rows were added in order):
+
+	ct.SortIndicators = true
+	ct.SetSortIndicator("Size", true)   // Size ▼
+
+SortBy() sorts by a column, comparing its values the way the column's SortAs says (see sortorder.go), and marks it as the
+column the rows are sorted by. SortByKeys() sorts by several columns at once, the first key first, and each key can
+compare its column's values its own way:
+
+	ct.SortBy("Name", false)
+	ct.SortByKeys(ctable.SortKey{Column: "Region"}, ctable.SortKey{Column: "Started", Descending: true, As: "time"})
+*/
+
+type SortKey struct {
+	Column     string
+	Descending bool
+	As         string // how the values compare - "text", "natural", "number", or "time", "" for the column's SortAs
+}
+
+// SortBy sorts the rows by the values in the named column, largest first if descending.
+func (ct *Table) SortBy(column string, descending bool) {
+	ct.SortByKeys(SortKey{Column: column, Descending: descending})
+}
+
+// SortByKeys sorts the rows by keys, by the first key then by each of the others in turn among rows the ones before
+// it don't tell apart. The sort indicator goes on the first key's column.
+func (ct *Table) SortByKeys(keys ...SortKey) {
+
+	if len(keys) == 0 {
+		return
+	}
+
+	cols := make([]int, len(keys))
+	for k, key := range keys {
+		if cols[k] = ct.columnIndex(key.Column); cols[k] < 0 {
+			ct.fatal("SortBy() column " + key.Column + " doesn't exist.")
+			return
+		}
+	}
+
+	ct.SortRows(func(a, b []string) bool {
+		for k, key := range keys {
+			sortAs := key.As
+			if sortAs == "" {
+				sortAs = ct.Columns[cols[k]].SortAs
+			}
+			if order := compareSorted(sortAs, a[cols[k]], b[cols[k]], key.Descending); order != 0 {
+				return order < 0
+			}
+		}
+		return false
+	})
+
+	ct.sortIndicator = &sortIndicator{column: cols[0], descending: keys[0].Descending}
+}
+
+// SortRows sorts the rows by less, which is passed the values of two rows (as Row() returns them), keeping the order
+// of rows less doesn't tell apart.
+func (ct *Table) SortRows(less func(a, b []string) bool) {
+
+	rows := make([][]string, ct.RowCount)
+	for r := range rows {
+		rows[r] = ct.Row(r)
+	}
+
+	order := ct.sortedRows()
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(rows[order[i]], rows[order[j]])
+	})
+
+	ct.sortOrder = order
+	ct.sortIndicator = nil
+	ct.windowLayout = nil
+}
+
+// UnsortRows puts the rows back in the order they were added.
+func (ct *Table) UnsortRows() {
+	ct.sortOrder = nil
+	ct.sortIndicator = nil
+	ct.windowLayout = nil
+}
+
+type sortIndicator struct {
+	column     int
+	descending bool
+}
+
+// SetSortIndicator marks the named column as the one the rows are sorted by (when SortIndicators is set), "" for none.
+func (ct *Table) SetSortIndicator(column string, descending bool) {
+
+	if column == "" {
+		ct.sortIndicator = nil
+		return
+	}
+
+	c := ct.columnIndex(column)
+	if c < 0 {
+		ct.fatal("SetSortIndicator() column " + column + " doesn't exist.")
+		return
+	}
+
+	ct.sortIndicator = &sortIndicator{column: c, descending: descending}
+	ct.windowLayout = nil
+}
+
+// sortMark returns what goes after the name of column c in the header to show the rows are sorted by it, "" if they're not
+func (ct *Table) sortMark(c int) string {
+
+	if !ct.SortIndicators || ct.sortIndicator == nil || ct.sortIndicator.column != c {
+		return ""
+	}
+
+	switch {
+	case ct.ASCII && ct.sortIndicator.descending:
+		return " v"
+	case ct.ASCII:
+		return " ^"
+	case ct.sortIndicator.descending:
+		return " ▼"
+	}
+
+	return " ▲"
+}
+
+// OriginalIndex returns the index (in the order rows were added) of the row in position pos of the sorted order.
+func (ct *Table) OriginalIndex(pos int) int {
+
+	if pos < len(ct.sortOrder) {
+		return ct.sortOrder[pos]
+	}
+
+	return pos
+}
+
+// sortedRows returns every row in the sorted order
+func (ct *Table) sortedRows() []int {
+
+	order := make([]int, ct.RowCount)
+	for pos := range order {
+		order[pos] = ct.OriginalIndex(pos)
+	}
+
+	return order
+}
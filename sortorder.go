@@ -0,0 +1,199 @@
+package ctable
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Sort order.
+
+Values are compared as plain text when rows are sorted by a column (in the viewer etc.), which puts "host10" before
+"host2" and "v1.10" before "v1.9". Setting a column's SortAs to "natural" compares the runs of digits in its values as
+numbers instead, so hostnames, versions, and file names come out in the order people expect:
+
+	ct.Columns[0].SortAs = "natural"   // host1, host2, host10
+
+The other ways of comparing are "number" (values compared as numbers, "1,024" included) and "time" (as dates and times -
+RFC 3339, "2006-01-02 15:04:05", "2006-01-02", and the like). Values that aren't numbers (or times) go after the ones
+that are (whichever way the rows are sorted), in text order among themselves. "" and "text" compare as plain text.
+*/
+
+// compareAs compares a and b as sortAs says (see SortAs), -1, 0, or +1 as for strings.Compare()
+func compareAs(sortAs string, a string, b string) int {
+
+	switch strings.ToLower(sortAs) {
+	case "natural":
+		return compareNatural(a, b)
+	case "number":
+		na, aok := parseNumber(a)
+		nb, bok := parseNumber(b)
+		if aok && bok {
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			}
+			return 0
+		}
+		return compareParsed(aok, bok, a, b)
+	case "time":
+		ta, aok := parseTime(a)
+		tb, bok := parseTime(b)
+		if aok && bok {
+			switch {
+			case ta.Before(tb):
+				return -1
+			case ta.After(tb):
+				return 1
+			}
+			return 0
+		}
+		return compareParsed(aok, bok, a, b)
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareSorted compares a and b for sorting as sortAs says, largest first if descending - values that sort last (see
+// sortsLast()) go after the rest either way
+func compareSorted(sortAs string, a string, b string, descending bool) int {
+
+	if la, lb := sortsLast(sortAs, a), sortsLast(sortAs, b); la != lb {
+		if la {
+			return 1
+		}
+		return -1
+	}
+
+	order := compareAs(sortAs, a, b)
+	if descending {
+		return -order
+	}
+
+	return order
+}
+
+// compareParsed orders two values that didn't both parse - the one that did first, as text if neither did
+func compareParsed(aok bool, bok bool, a string, b string) int {
+	switch {
+	case aok:
+		return -1
+	case bok:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// sortsLast reports whether s is a value that goes after the rest when compared as sortAs says - a number or a time that
+// doesn't parse as one
+func sortsLast(sortAs string, s string) bool {
+
+	switch strings.ToLower(sortAs) {
+	case "number":
+		_, ok := parseNumber(s)
+		return !ok
+	case "time":
+		_, ok := parseTime(s)
+		return !ok
+	}
+
+	return false
+}
+
+// parseNumber returns s as a number, ok is false if it isn't one
+func parseNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(stripANSI(s)), ",", ""), 64)
+	return f, err == nil
+}
+
+// the layouts times are parsed with for sorting, most specific first
+var sortTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.UnixDate,
+	time.Kitchen,
+	"15:04:05",
+	"15:04",
+}
+
+// parseTime returns s as a time, ok is false if it isn't one in any of sortTimeLayouts
+func parseTime(s string) (time.Time, bool) {
+
+	s = strings.TrimSpace(stripANSI(s))
+	for _, layout := range sortTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// compareNatural compares a and b with runs of digits compared by their numeric value - equal numbers with more leading
+// zeros go after, when nothing else tells the values apart
+func compareNatural(a string, b string) int {
+
+	zeros := 0
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			da, db := digitRun(a), digitRun(b)
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			switch {
+			case len(na) != len(nb):
+				return compareInts(len(na), len(nb))
+			case na != nb:
+				return strings.Compare(na, nb)
+			}
+			if zeros == 0 {
+				zeros = compareInts(len(da), len(db))
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return compareInts(int(a[0]), int(b[0]))
+		}
+		a, b = a[1:], b[1:]
+	}
+
+	if order := compareInts(len(a), len(b)); order != 0 {
+		return order
+	}
+
+	return zeros
+}
+
+// digitRun returns the digits s starts with
+func digitRun(s string) string {
+
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+
+	return s[:n]
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
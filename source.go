@@ -0,0 +1,105 @@
+package ctable
+
+/*
+Row sources.
+
+A table holds all its rows, which is a problem for tables over database cursors, paginated APIs, or files too big to
+read in. DisplaySource() pulls the rows from a RowSource instead, a batch at a time, printing each batch as it goes,
+so only one batch is ever held in the table:
+
+	rows, _ := db.Query("SELECT name, size FROM files")
+	ct.DisplaySource(true, ctable.RowSourceFunc(func() ([]string, bool) {
+		if !rows.Next() {
+			return nil, false
+		}
+		var name, size string
+		rows.Scan(&name, &size)
+		return []string{name, size}, true
+	}))
+
+Column widths are worked out from the first batch (the whole source when it's no bigger than that), values in later
+rows too wide for their column are cut short so the table still lines up.
+*/
+
+// RowSource supplies rows one at a time - Next returns the fields of the next row, ok is false when there are no more.
+type RowSource interface {
+	Next() (fields []string, ok bool)
+}
+
+// RowSourceFunc makes a plain function a RowSource.
+type RowSourceFunc func() ([]string, bool)
+
+// Next calls f.
+func (f RowSourceFunc) Next() ([]string, bool) {
+	return f()
+}
+
+// number of rows DisplaySource() reads in before printing them, the first batch sets the column widths
+const sourceBatchRows = 500
+
+// DisplaySource displays the rows read from src, a batch at a time. The table supplies the columns and settings,
+// any rows it has are dropped (and afterwards it holds the last batch read).
+func (ct *Table) DisplaySource(showHeaders bool, src RowSource) {
+	ct.displaySource(showHeaders, src, sourceBatchRows)
+}
+
+// displaySource is DisplaySource() reading batchRows rows at a time
+func (ct *Table) displaySource(showHeaders bool, src RowSource, batchRows int) {
+
+	var lay layout
+	var f *framer
+	var cols []int
+
+	for batch := 0; ; batch++ {
+
+		ct.clearRows()
+		more := ct.readBatch(src, batchRows)
+		if ct.RowCount == 0 && batch > 0 {
+			break
+		}
+
+		if batch == 0 {
+			lay = ct.computeLayout()
+			cols = lay.columns()
+			f = ct.framer(lay, cols)
+			ct.printTop(showHeaders, lay, cols, f)
+		} else {
+			// later batches keep the first batch's widths (and hidden columns) so everything lines up
+			next := ct.computeLayout()
+			next.widths, next.hidden, next.fixed = lay.widths, lay.hidden, true
+			lay = next
+		}
+		ct.printLines(lay, cols, f, batch > 0)
+
+		if !more {
+			break
+		}
+	}
+
+	ct.printBottom(f)
+}
+
+// readBatch adds up to batchRows rows from src to the table, more is false once src has run out
+func (ct *Table) readBatch(src RowSource, batchRows int) (more bool) {
+
+	for ct.RowCount < batchRows {
+		fields, ok := src.Next()
+		if !ok {
+			return false
+		}
+		ct.addStrings(fields)
+	}
+
+	return true
+}
+
+// addStrings is AddRow() for a row of strings
+func (ct *Table) addStrings(fields []string) {
+
+	row := make([]interface{}, len(fields))
+	for i, field := range fields {
+		row[i] = field
+	}
+
+	ct.AddRow(row...)
+}
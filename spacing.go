@@ -0,0 +1,96 @@
+package ctable
+
+/*
+Space between multiline rows.
+
+Consecutive rows that each run to several lines blur together. With SeparateMultilineRows set, every row that takes up
+more than one line is followed by a blank line, or by a light rule across the table when MultilineRule is set, e.g.
+
+	ct.SeparateMultilineRows = true
+	ct.MultilineRule = "·"
+
+Sorted data falls into groups of rows with the same value in a key column. Setting GroupColumn to the key column's name
+puts a separator line between the groups, wherever the value changes from one row to the next - a blank line, or
+GroupRule repeated across the table if set:
+
+	ct.GroupColumn = "Region"
+*/
+
+// separatorLine stands in the layout's line order for the line after a multiline row, and groupLine for the line between two groups
+const (
+	separatorLine = -1
+	groupLine     = -2
+)
+
+// isSeparatorLine reports whether l (from the layout's line order) is a separator rather than a display line
+func isSeparatorLine(l int) bool {
+	return l == separatorLine || l == groupLine
+}
+
+// separateMultilineRows adds a separator line to the layout after each multiline row (other than the last row)
+func (ct *Table) separateMultilineRows(lay *layout) {
+
+	if !ct.SeparateMultilineRows {
+		return
+	}
+
+	order := make([]int, 0, lay.lines)
+	for pos := 0; pos < lay.rows; pos++ {
+		n := len(order)
+		order = ct.appendRowLines(order, *lay, lay.rowAt(pos))
+		if len(order)-n > 1 && pos < lay.rows-1 {
+			order = append(order, separatorLine)
+		}
+	}
+
+	lay.lineOrder = order
+	lay.lines = len(order)
+}
+
+// separateGroups adds a separator line to the layout in front of each row whose GroupColumn value differs from the row above
+// (in place of any separator after a multiline row)
+func (ct *Table) separateGroups(lay *layout) {
+
+	if ct.GroupColumn == "" {
+		return
+	}
+	key := ct.columnIndex(ct.GroupColumn)
+	if key < 0 {
+		ct.fatal("GroupColumn " + ct.GroupColumn + " doesn't exist.")
+		return
+	}
+
+	rowStarts := ct.rowStartLines(*lay)
+	order := make([]int, 0, lay.lines)
+	previous, started := "", false
+	for i := 0; i < lay.lines; i++ {
+		l := lay.lineAt(i)
+		if !rowStarts[l] {
+			order = append(order, l)
+			continue
+		}
+		value := ct.cell(l, key)
+		if started && value != previous {
+			if n := len(order); n > 0 && order[n-1] == separatorLine {
+				order = order[:n-1]
+			}
+			order = append(order, groupLine)
+		}
+		previous, started = value, true
+		order = append(order, l)
+	}
+
+	lay.lineOrder = order
+	lay.lines = len(order)
+}
+
+// separatorText returns separator line l for a line width characters wide
+func (ct *Table) separatorText(l int, width int) string {
+
+	rule := ct.MultilineRule
+	if l == groupLine {
+		rule = ct.GroupRule
+	}
+
+	return colorize(repeatToWidth(rule, width), ct.Theme.BorderColor)
+}
@@ -0,0 +1,59 @@
+package ctable
+
+/*
+Stable column widths.
+
+Each Display() sizes the columns to the rows at hand, so a table printed again after more rows have been added can come
+out with different widths than the copy already on screen. With StableWidths set, the widths the table is first displayed
+at are kept for every Display() after it, whatever rows come along:
+
+	ct.StableWidths = true
+	ct.Display(true)
+	...
+	ct.AddRow(...)
+	ct.Display(false)   // lines up with the table above
+
+SetColumnWidths() sets the widths up front instead, e.g. from a saved layout or to match another table. Either way values
+too wide for their column are cut short (and column names too), and UnlockWidths() goes back to sizing the columns to
+their values.
+*/
+
+// SetColumnWidths fixes the width of each column (one width per column, 0 or less leaves that column sized to its values).
+func (ct *Table) SetColumnWidths(widths ...int) {
+
+	if len(widths) != ct.ColumnCount {
+		ct.fatal("SetColumnWidths() needs a width for each column.")
+		return
+	}
+
+	ct.lockedWidths = append([]int(nil), widths...)
+	ct.windowLayout = nil
+}
+
+// UnlockWidths drops the widths kept by StableWidths or set with SetColumnWidths().
+func (ct *Table) UnlockWidths() {
+	ct.lockedWidths = nil
+	ct.windowLayout = nil
+}
+
+// lockWidths keeps the widths the table's being displayed at for later displays, when StableWidths is set and they aren't kept already
+func (ct *Table) lockWidths(lay layout) {
+	if ct.StableWidths && ct.lockedWidths == nil {
+		ct.lockedWidths = append([]int(nil), lay.widths...)
+	}
+}
+
+// applyLockedWidths puts the locked widths (if any) into the layout
+func (ct *Table) applyLockedWidths(lay *layout) {
+
+	if ct.lockedWidths == nil {
+		return
+	}
+
+	for i, width := range ct.lockedWidths {
+		if width > 0 {
+			lay.widths[i], lay.widthReasons[i] = width, "locked"
+			lay.fixed = true
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ctable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Column statistics.
+
+Stats() summarises a column's values, for report footers ("12 hosts, 3 regions") and for deciding on a layout in code,
+e.g. dropping a column that's nearly all the same value, or right justifying one that turns out to be numeric.
+*/
+
+// ColumnStats summarises the values in a column.
+type ColumnStats struct {
+	Count    int // rows with a value (not blank or EmptyValue)
+	Empty    int // rows without one
+	Distinct int // different values among those counted
+
+	// display width of the narrowest and widest value (the widest line of multiline values), 0 when there are none
+	MinWidth int
+	MaxWidth int
+
+	// set when every value is a number, along with the smallest, largest, and average of them
+	Numeric bool
+	Min     float64
+	Max     float64
+	Mean    float64
+}
+
+// Stats returns the statistics for the values in the named column, across every row (in collapsed groups too).
+func (ct *Table) Stats(column string) (ColumnStats, error) {
+
+	c := ct.columnIndex(column)
+	if c < 0 {
+		return ColumnStats{}, fmt.Errorf("CONSOLETABLE: no column named %q", column)
+	}
+
+	var stats ColumnStats
+	distinct := map[string]bool{}
+	sum := 0.0
+	stats.Numeric = true
+
+	for r := 0; r < ct.RowCount; r++ {
+		value := ct.Row(r)[c]
+		if strings.TrimSpace(value) == "" || value == ct.EmptyValue {
+			stats.Empty++
+			continue
+		}
+
+		stats.Count++
+		distinct[value] = true
+
+		width := 0
+		for _, line := range strings.Split(value, "\n") {
+			if w := textWidth(line); w > width {
+				width = w
+			}
+		}
+		if stats.Count == 1 || width < stats.MinWidth {
+			stats.MinWidth = width
+		}
+		if width > stats.MaxWidth {
+			stats.MaxWidth = width
+		}
+
+		if !stats.Numeric {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(stripANSI(value)), 64)
+		if err != nil {
+			stats.Numeric = false
+			continue
+		}
+		if stats.Count == 1 || n < stats.Min {
+			stats.Min = n
+		}
+		if stats.Count == 1 || n > stats.Max {
+			stats.Max = n
+		}
+		sum += n
+	}
+
+	stats.Distinct = len(distinct)
+
+	if stats.Count == 0 || !stats.Numeric {
+		stats.Numeric = false
+		stats.Min, stats.Max = 0, 0
+		return stats, nil
+	}
+	stats.Mean = sum / float64(stats.Count)
+
+	return stats, nil
+}
@@ -0,0 +1,258 @@
+package ctable
+
+import "strings"
+
+/*
+Row storage.
+
+Rather than a slice per row of boxed interface{} values, every field of every display line is stored as a string
+in one flat backing slice, ColumnCount fields per line. Rows with multiline values take up several consecutive
+display lines, rowStarts records where each row begins. Original (pre string conversion) values are only kept,
+in a flat side table of their own, when KeepRawValues is set. The Rows field that used to hold the rows is gone, Rows()
+returns the display lines the way it had them for code that read it.
+
+Optionally (UseColumnarStorage()) the fields are stored column-major instead, a slice per column,
+so anything that works a column at a time (width scanning, per-column formatting, aggregation)
+walks contiguous memory rather than striding across every line.
+
+For bulk loads where the number of rows is known (or can be guessed) up front, NewTableWithCapacity() and Grow()
+allocate the storage once rather than it being grown again and again as rows are added. Rows with multiline values
+take more than one line each, so room is made for rows of one line.
+*/
+
+// NewTableWithCapacity is NewTable() with room for expectedRows rows.
+func NewTableWithCapacity(columns []Column, expectedRows int) Table {
+
+	ct := NewTable(columns)
+	ct.Grow(expectedRows)
+
+	return ct
+}
+
+// Grow makes room for n more rows, so adding that many doesn't have to allocate storage along the way.
+func (ct *Table) Grow(n int) {
+
+	if n <= 0 {
+		return
+	}
+
+	if ct.columnar {
+		for c := range ct.columnCells {
+			ct.columnCells[c] = grow(ct.columnCells[c], n)
+		}
+	} else {
+		ct.cells = grow(ct.cells, n*ct.ColumnCount)
+	}
+
+	ct.rowStarts = grow(ct.rowStarts, n)
+	if ct.KeepRawValues {
+		ct.rawValues = grow(ct.rawValues, n*ct.ColumnCount)
+	}
+	if ct.parents != nil {
+		ct.parents = grow(ct.parents, n)
+	}
+}
+
+// grow returns s with room for n more elements
+func grow[T any](s []T, n int) []T {
+
+	if cap(s)-len(s) >= n {
+		return s
+	}
+
+	return append(make([]T, 0, len(s)+n), s...)
+}
+
+// UseColumnarStorage switches the table to column-major storage, any rows already added are moved over.
+func (ct *Table) UseColumnarStorage() {
+
+	if ct.columnar {
+		return
+	}
+
+	lines := ct.lineCount()
+	ct.columnCells = make([][]string, ct.ColumnCount)
+
+	for c := range ct.columnCells {
+		ct.columnCells[c] = make([]string, lines)
+		for l := 0; l < lines; l++ {
+			ct.columnCells[c][l] = ct.cells[l*ct.ColumnCount+c]
+		}
+	}
+
+	ct.cells = nil
+	ct.columnar = true
+}
+
+// appendCell stores the value of field c of a new display line, fields have to be appended in column order
+func (ct *Table) appendCell(c int, value string) {
+
+	if ct.columnar {
+		ct.columnCells[c] = append(ct.columnCells[c], value)
+		return
+	}
+
+	ct.cells = append(ct.cells, value)
+}
+
+// cell returns field c of display line l
+func (ct *Table) cell(l int, c int) string {
+
+	if ct.lazyCells != nil {
+		ct.resolveCell(l, c)
+	}
+
+	return ct.storedCell(l, c)
+}
+
+// storedCell returns field c of display line l as it's stored, blank for a lazy value that hasn't been worked out
+func (ct *Table) storedCell(l int, c int) string {
+
+	if ct.columnar {
+		return ct.columnCells[c][l]
+	}
+
+	return ct.cells[l*ct.ColumnCount+c]
+}
+
+// setCell replaces field c of display line l
+func (ct *Table) setCell(l int, c int, value string) {
+
+	if ct.columnar {
+		ct.columnCells[c][l] = value
+		return
+	}
+
+	ct.cells[l*ct.ColumnCount+c] = value
+}
+
+// scanColumn calls fn with field c of every display line in turn
+func (ct *Table) scanColumn(c int, fn func(value string)) {
+
+	if ct.columnar {
+		for _, value := range ct.columnCells[c] {
+			fn(value)
+		}
+		return
+	}
+
+	for i := c; i < len(ct.cells); i += ct.ColumnCount {
+		fn(ct.cells[i])
+	}
+}
+
+// lineCount returns the number of display lines stored
+func (ct *Table) lineCount() int {
+	if ct.ColumnCount == 0 {
+		return 0
+	}
+	if ct.columnar {
+		return len(ct.columnCells[0])
+	}
+	return len(ct.cells) / ct.ColumnCount
+}
+
+// line returns the fields of display line l (capped so an append can't clobber the next line, and a copy with columnar storage)
+func (ct *Table) line(l int) []string {
+
+	if ct.lazyCells != nil {
+		ct.resolveLine(l)
+	}
+
+	if ct.columnar {
+		fields := make([]string, ct.ColumnCount)
+		for c := range fields {
+			fields[c] = ct.columnCells[c][l]
+		}
+		return fields
+	}
+
+	start := l * ct.ColumnCount
+	end := start + ct.ColumnCount
+	return ct.cells[start:end:end]
+}
+
+// rowLines returns the range of display lines, first up to but not including end, that row i takes up
+func (ct *Table) rowLines(i int) (first int, end int) {
+	first = ct.rowStarts[i]
+	if i+1 < len(ct.rowStarts) {
+		return first, ct.rowStarts[i+1]
+	}
+	return first, ct.lineCount()
+}
+
+// Row returns the field values of row i (in the order rows were added), multiline values are joined by newlines.
+func (ct *Table) Row(i int) []string {
+	return ct.joinedRow(i, ct.cell)
+}
+
+// joinedRow returns the field values of row i as Row() does, each line's fields read with cell
+func (ct *Table) joinedRow(i int, cell func(l int, c int) string) []string {
+
+	first, end := ct.rowLines(i)
+	row := make([]string, ct.ColumnCount)
+
+	for c := range row {
+		values := make([]string, 0, end-first)
+		for l := first; l < end; l++ {
+			values = append(values, cell(l, c))
+		}
+		// shorter multiline values (and single values next to multiline ones) are padded out with blank lines, drop those
+		row[c] = strings.TrimRight(strings.Join(values, "\n"), "\n")
+	}
+
+	return row
+}
+
+// Rows returns every display line's fields, for code written against the Rows field this storage replaced - a line per
+// element as the field had (a row with multiline values takes a line per line of them), the fields as strings.
+// Row() is the way to read a row.
+func (ct *Table) Rows() [][]interface{} {
+
+	lines := make([][]interface{}, ct.lineCount())
+	for l := range lines {
+		lines[l] = make([]interface{}, ct.ColumnCount)
+		for c := range lines[l] {
+			lines[l][c] = ct.cell(l, c)
+		}
+	}
+
+	return lines
+}
+
+// clearRows drops all the rows, leaving the columns and settings as they are (column widths go back to fitting just the names)
+func (ct *Table) clearRows() {
+
+	ct.cells = ct.cells[:0]
+	for c := range ct.columnCells {
+		ct.columnCells[c] = ct.columnCells[c][:0]
+	}
+	ct.rowStarts = ct.rowStarts[:0]
+	ct.rawValues = ct.rawValues[:0]
+	ct.lazyCells = nil
+	ct.parents = nil
+	ct.collapsed = nil
+	ct.sortOrder = nil
+	ct.annotations = nil
+	ct.cellJustifications = nil
+	ct.rowStyles, ct.cellStyles = nil, nil
+	ct.RowCount = 0
+
+	for i := range ct.Columns {
+		ct.Columns[i].maxLength = textWidth(ct.Columns[i].Name)
+		ct.Columns[i].setTruncateAt(ct.Columns[i].truncateAt)
+	}
+
+	ct.windowLayout = nil
+}
+
+// RawValue returns the value originally passed to AddRow() for field col of row, or nil if KeepRawValues wasn't set when it was added.
+func (ct *Table) RawValue(row int, col int) interface{} {
+
+	i := row*ct.ColumnCount + col
+	if i >= len(ct.rawValues) {
+		return nil
+	}
+
+	return ct.rawValues[i]
+}
@@ -0,0 +1,200 @@
+package ctable
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+Tables from structs.
+
+FromStructs() builds a table from a slice of structs, a column per exported field (embedded structs' fields included)
+and a row per element. NewTableForStruct() creates the empty table for a struct type, and AddStruct() adds rows to it.
+
+How each field is shown is controlled by a ctable struct tag, keeping the presentation next to the data type:
+
+	type Host struct {
+		Name    string    `ctable:"Host Name"`
+		Load    float64   `ctable:",right,precision=2"`
+		Comment string    `ctable:"Notes,trunc=20"`
+		Secret  string    `ctable:",omit"`           // (or `ctable:"-"`)
+	}
+
+The first tag value is the column name (the field name when empty), the options after it:
+
+	left, right, center, decimal   justification
+	trunc=N                        truncate values at N characters
+	min=N                          minimum column width
+	precision=N                    decimal places for float values
+	omit                           leave the field out of the table
+
+Field values are added as they are when AddRow() supports their type (strings, numbers, errors, encoding.TextMarshaler,
+[]string), nil pointers as EmptyValue, anything else as formatted by fmt.Sprint().
+*/
+
+// structColumn is a struct field shown as a column
+type structColumn struct {
+	index  []int // field index (path, for fields of embedded structs)
+	column Column
+}
+
+// NewTableForStruct creates a new (empty) table with a column per exported field of v's struct type
+// (v can be a struct, a pointer to one, or a reflect.Type), see AddStruct().
+func NewTableForStruct(v interface{}) Table {
+
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		ct := NewTable(nil)
+		ct.fatal("NewTableForStruct() needs a struct type.")
+		return ct
+	}
+
+	fields := structColumns(t)
+	columns := make([]Column, len(fields))
+	index := make([][]int, len(fields))
+	for i, f := range fields {
+		columns[i] = f.column
+		index[i] = f.index
+	}
+
+	ct := NewTable(columns)
+	ct.structType = t
+	ct.structFields = index
+
+	return ct
+}
+
+// FromStructs creates a table from items, a slice (or array) of structs or pointers to structs.
+func FromStructs(items interface{}) Table {
+
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		ct := NewTable(nil)
+		ct.fatal("FromStructs() needs a slice of structs.")
+		return ct
+	}
+
+	ct := NewTableForStruct(v.Type().Elem())
+	for i := 0; i < v.Len(); i++ {
+		ct.AddStruct(v.Index(i).Interface())
+	}
+
+	return ct
+}
+
+// AddStruct adds a row with the fields of item, which has to be of the struct type the table was created for (or a pointer to one).
+func (ct *Table) AddStruct(item interface{}) {
+
+	v := reflect.ValueOf(item)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	// (a nil item has no type to check)
+	if ct.structType == nil || !v.IsValid() || v.Type() != ct.structType {
+		ct.fatal("AddStruct() needs a value of the struct type the table was created for (see NewTableForStruct()).")
+		return
+	}
+
+	fields := make([]interface{}, len(ct.structFields))
+	for i, index := range ct.structFields {
+		fields[i] = structFieldValue(v, index)
+	}
+
+	ct.AddRow(fields...)
+}
+
+// structColumns returns the columns for the exported fields of struct type t, per their tags
+func structColumns(t reflect.Type) []structColumn {
+
+	columns := []structColumn{}
+
+	for _, f := range reflect.VisibleFields(t) {
+		// embedded structs (and pointers to them) have their fields listed after them, they aren't columns themselves
+		embedded := f.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if !f.IsExported() || f.Anonymous && embedded.Kind() == reflect.Struct {
+			continue
+		}
+
+		tag := strings.Split(f.Tag.Get("ctable"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+
+		name := strings.TrimSpace(tag[0])
+		if name == "" {
+			name = f.Name
+		}
+
+		col := NewColumn(name, 0)
+		omit := false
+		for _, opt := range tag[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+			n, _ := strconv.Atoi(value)
+			switch key {
+			case "left", "right", "center", "decimal":
+				col.Justification = Justification(key)
+			case "trunc":
+				col.setTruncateAt(n)
+			case "min":
+				col.MinWidth = n
+			case "precision":
+				col.Precision = n
+			case "omit":
+				omit = true
+			}
+		}
+
+		if !omit {
+			columns = append(columns, structColumn{index: f.Index, column: col})
+		}
+	}
+
+	return columns
+}
+
+// structFieldValue returns the field of v at index as a value AddRow() accepts
+func structFieldValue(v reflect.Value, index []int) interface{} {
+
+	// fields of embedded structs through a nil pointer are empty
+	f, err := v.FieldByIndexErr(index)
+	if err != nil || !f.CanInterface() {
+		return nil
+	}
+
+	return fieldValue(f)
+}
+
+// fieldValue returns f as a value AddRow() accepts
+func fieldValue(f reflect.Value) interface{} {
+
+	if (f.Kind() == reflect.Pointer || f.Kind() == reflect.Interface) && f.IsNil() {
+		return nil
+	}
+
+	value := f.Interface()
+	switch value.(type) {
+	case string, []string, error, encoding.TextMarshaler,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	}
+
+	if f.Kind() == reflect.Pointer {
+		return fieldValue(f.Elem())
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,50 @@
+package ctable
+
+import (
+	"testing"
+)
+
+type testBase struct {
+	ID int
+}
+
+type testExtra struct {
+	Zone string
+}
+
+type testHost struct {
+	testBase
+	*testExtra
+	Name string `ctable:"Host"`
+}
+
+func TestFromStructsEmbedded(t *testing.T) {
+
+	ct := FromStructs([]testHost{
+		{testBase{1}, &testExtra{"eu"}, "web-1"},
+		{testBase{2}, nil, "db-1"},
+	})
+
+	want := `ID Zone Host
+== ==== =====
+1  eu   web-1
+2       db-1
+`
+	if got := ct.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAddStructNil(t *testing.T) {
+
+	logger := &testLogger{}
+	ct := NewTableForStruct(testHost{})
+	ct.Logger = logger
+
+	ct.AddStruct(nil)
+	ct.AddStruct((*testHost)(nil))
+
+	if ct.RowCount != 0 || len(logger.fatals) != 2 {
+		t.Errorf("got %d rows and %d fatal diagnostics, want 0 and 2", ct.RowCount, len(logger.fatals))
+	}
+}
@@ -0,0 +1,231 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Styles.
+
+A Style is a set of ANSI text attributes - a color (as an SGR code, the same as HeaderColor, ErrorColor etc.) plus
+bold, dim, italic, underline, and reverse. Styles only change how text looks, widths are measured without the escape
+sequences so columns still line up.
+
+Rows can be styled by their values with StyleRowsWhere(), e.g. to dim terminated instances:
+
+	ct.StyleRowsWhere("Status", func(v string) bool { return v == "terminated" }, ctable.Style{Dim: true})
+
+Grouped data reads better striped a group at a time than a row at a time, StripeGroups() styles every other run of
+rows sharing a value in the key column:
+
+	ct.StripeGroups("Region", ctable.Style{Color: "48;5;236"})
+
+Or styled directly - a whole row with StyleRow(), a single value with StyleCell(), every value in a column with its
+Style, and a column's name in the header with its HeaderStyle:
+
+	ct.StyleRow(3, ctable.Style{Bold: true})
+	ct.StyleCell(3, 2, ctable.Style{Color: "31"})
+	ct.Columns[2].Style = ctable.Style{Color: "32"}
+	ct.Columns[2].HeaderStyle = ctable.Style{Underline: true}
+
+A row styled with StyleRow() takes that style over any StyleRowsWhere() or StripeGroups() style. Column and cell styles
+go on top of the row's (a cell's style over its column's), on the values themselves rather than the padding around them.
+*/
+
+type Style struct {
+	Color     string // ANSI SGR code(s), e.g. "31" for red or "1;44" for bold on blue, "" for none
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+}
+
+// code returns the SGR parameters for the style, "" for the zero Style
+func (st Style) code() string {
+
+	codes := []string{}
+	if st.Bold {
+		codes = append(codes, "1")
+	}
+	if st.Dim {
+		codes = append(codes, "2")
+	}
+	if st.Italic {
+		codes = append(codes, "3")
+	}
+	if st.Underline {
+		codes = append(codes, "4")
+	}
+	if st.Reverse {
+		codes = append(codes, "7")
+	}
+	if st.Color != "" {
+		codes = append(codes, st.Color)
+	}
+
+	return strings.Join(codes, ";")
+}
+
+// apply returns s in the style, styling inside s (colored errors, separators etc.) ends with a reset,
+// so the style is started again after each one to carry on through the rest of s
+func (st Style) apply(s string) string {
+
+	code := st.code()
+	if code == "" {
+		return s
+	}
+
+	return colorize(strings.ReplaceAll(s, ansiReset, ansiReset+"\x1b["+code+"m"), code)
+}
+
+type rowStyleRule struct {
+	column int
+	pred   func(string) bool
+	style  Style
+}
+
+// StyleRowsWhere styles whole rows whose value in the named column satisfies pred (multiline values are passed joined with "\n").
+// Rules are checked in the order they were added, the last one that matches a row wins.
+func (ct *Table) StyleRowsWhere(column string, pred func(string) bool, style Style) {
+
+	c := ct.columnIndex(column)
+	if c < 0 {
+		ct.fatal("StyleRowsWhere() column " + column + " doesn't exist.")
+		return
+	}
+
+	ct.rowStyleRules = append(ct.rowStyleRules, rowStyleRule{column: c, pred: pred, style: style})
+	ct.windowLayout = nil
+}
+
+type groupStripe struct {
+	column int // key column, -1 to stripe row by row
+	style  Style
+}
+
+// StripeGroups styles alternate groups of rows, a group being consecutive rows (as displayed) with the same value in the
+// named column, "" for plain row by row striping. Rows matched by StyleRowsWhere() take that style instead.
+func (ct *Table) StripeGroups(column string, style Style) {
+
+	c := -1
+	if column != "" {
+		if c = ct.columnIndex(column); c < 0 {
+			ct.fatal("StripeGroups() column " + column + " doesn't exist.")
+			return
+		}
+	}
+
+	ct.stripe = &groupStripe{column: c, style: style}
+	ct.windowLayout = nil
+}
+
+// StyleRow styles row i (in the order rows were added), the zero Style goes back to any style from StyleRowsWhere() or StripeGroups().
+func (ct *Table) StyleRow(i int, style Style) {
+
+	if i < 0 || i >= ct.RowCount {
+		ct.fatal("StyleRow() row index is out of range.")
+		return
+	}
+
+	if ct.rowStyles == nil {
+		ct.rowStyles = map[int]Style{}
+	}
+
+	if style == (Style{}) {
+		delete(ct.rowStyles, i)
+	} else {
+		ct.rowStyles[i] = style
+	}
+	ct.windowLayout = nil
+}
+
+// StyleCell styles field col of row, the zero Style goes back to the column's.
+func (ct *Table) StyleCell(row int, col int, style Style) {
+
+	if row < 0 || row >= ct.RowCount || col < 0 || col >= ct.ColumnCount {
+		ct.fatal("StyleCell() row or column index is out of range.")
+		return
+	}
+
+	if ct.cellStyles == nil {
+		ct.cellStyles = map[int]Style{}
+	}
+
+	first, end := ct.rowLines(row)
+	for l := first; l < end; l++ {
+		if style == (Style{}) {
+			delete(ct.cellStyles, l*ct.ColumnCount+col)
+		} else {
+			ct.cellStyles[l*ct.ColumnCount+col] = style
+		}
+	}
+	ct.windowLayout = nil
+}
+
+// cellStyle returns the style of field c of display line l, its own if it has one, otherwise the column's
+func (ct *Table) cellStyle(l int, c int) Style {
+
+	if st, ok := ct.cellStyles[l*ct.ColumnCount+c]; ok {
+		return st
+	}
+
+	return ct.Columns[c].Style
+}
+
+// styleLayout works out the style of each display line from the group striping, row style rules, and styled rows
+func (ct *Table) styleLayout(lay *layout) {
+
+	if len(ct.rowStyleRules) == 0 && ct.stripe == nil && len(ct.rowStyles) == 0 {
+		return
+	}
+
+	lay.lineStyles = make([]Style, ct.lineCount())
+
+	striped := false
+	key := ""
+
+	for pos := 0; pos < lay.rows; pos++ {
+		r := lay.rowAt(pos)
+		row := ct.Row(r)
+
+		var style Style
+		if ct.stripe != nil {
+			if ct.stripe.column < 0 {
+				striped = pos%2 == 1
+			} else if pos > 0 && row[ct.stripe.column] != key {
+				striped = !striped
+			}
+			if ct.stripe.column >= 0 {
+				key = row[ct.stripe.column]
+			}
+			if striped {
+				style = ct.stripe.style
+			}
+		}
+
+		for _, rule := range ct.rowStyleRules {
+			if rule.pred(row[rule.column]) {
+				style = rule.style
+			}
+		}
+		if st, ok := ct.rowStyles[r]; ok {
+			style = st
+		}
+
+		first, end := ct.rowLines(r)
+		for l := first; l < end; l++ {
+			lay.lineStyles[l] = style
+		}
+	}
+}
+
+// styleLine returns s (part of display line l) in the line's style
+func (lay layout) styleLine(l int, s string) string {
+
+	if lay.lineStyles == nil {
+		return s
+	}
+
+	return lay.lineStyles[l].apply(s)
+}
@@ -0,0 +1,141 @@
+package ctable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+SVG output.
+
+RenderSVG() draws the table as an SVG image - monospace text placed per column from the same layout Display() uses,
+with optional grid lines between rows and columns - for documentation and web pages, where it stays crisp at any size.
+
+	f, _ := os.Create("hosts.svg")
+	err := ct.RenderSVG(f, ctable.SVGOptions{ShowHeaders: true, Grid: true})
+
+Colors and other ANSI styling in the table aren't carried over, the column names are drawn in bold.
+*/
+
+type SVGOptions struct {
+	ShowHeaders bool
+	Grid        bool    // lines between rows and columns, and a border around the table
+	FontSize    float64 // in pixels, 0 for 14
+}
+
+// RenderSVG writes the table to w as an SVG image.
+func (ct *Table) RenderSVG(w io.Writer, opts SVGOptions) error {
+
+	lay := ct.computeLayout()
+
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = 14
+	}
+	charWidth := fontSize * 0.6 // monospace fonts are close enough to 0.6em wide
+	lineHeight := fontSize * 1.4
+	margin := fontSize / 2
+
+	// character position each column shown starts at
+	cols := lay.columns()
+	gapWidth := textWidth(lay.gap)
+	starts := make([]int, len(cols))
+	for i := 1; i < len(cols); i++ {
+		starts[i] = starts[i-1] + lay.widths[cols[i-1]] + gapWidth
+	}
+
+	width := lay.totalWidth()
+	if w := lay.summaryWidth(); w > width {
+		width = w
+	}
+
+	headerLines := 0
+	if opts.ShowHeaders {
+		headerLines = 1
+	}
+	svgWidth := float64(width)*charWidth + 2*margin
+	svgHeight := float64(headerLines+lay.lines)*lineHeight + 2*margin
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%.1f" viewBox="0 0 %.1f %.1f">`+"\n", svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(bw, `<g font-family="monospace" font-size="%.1f" fill="#000000" xml:space="preserve">`+"\n", fontSize)
+
+	// x of character position c, and y of the baseline of line n (counting the header)
+	x := func(c int) float64 { return margin + float64(c)*charWidth }
+	baseline := func(n int) float64 { return margin + float64(n)*lineHeight + fontSize }
+	text := func(c int, n int, s string, attrs string) {
+		if s = strings.TrimRight(stripANSI(s), " "); strings.TrimSpace(s) == "" {
+			return
+		}
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f"%s>`, x(c), baseline(n), attrs)
+		bw.WriteString(ct.escape("svg", s))
+		fmt.Fprintln(bw, "</text>")
+	}
+
+	if opts.ShowHeaders {
+		for i, c := range cols {
+			name, _ := ct.formatHeaderField(c, lay)
+			text(starts[i], 0, name, ` font-weight="bold"`)
+		}
+	}
+
+	// first lines of rows, where the grid's row lines go
+	rowFirst := ct.rowStartLines(lay)
+
+	var grid []string
+	hline := func(n int) {
+		y := margin + float64(n)*lineHeight
+		grid = append(grid, fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`, margin/2, y, svgWidth-margin/2, y))
+	}
+
+	for i := 0; i < lay.lines; i++ {
+		n := headerLines + i
+		l := lay.lineAt(i)
+
+		if opts.Grid && rowFirst[l] && i > 0 {
+			hline(n)
+		}
+
+		_, isAnnotation := isAnnotationLine(l)
+		switch summary, isSummary := lay.summaries[l]; {
+		case isAnnotation:
+			text(0, n, ct.formatLineColumns(l, cols, lay), "")
+		case isSeparatorLine(l):
+			continue
+		case isSummary:
+			text(0, n, lay.prefixes[l]+summary, "")
+		default:
+			for i, c := range cols {
+				text(starts[i], n, ct.formatField(l, c, lay), "")
+			}
+		}
+	}
+
+	fmt.Fprintln(bw, "</g>")
+
+	if opts.ShowHeaders && (opts.Grid || ct.Theme.HeaderSeparator != "") {
+		hline(1)
+	}
+	if opts.Grid {
+		for i := 1; i < len(cols); i++ {
+			xc := x(starts[i]) - float64(gapWidth)*charWidth/2
+			grid = append(grid, fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`, xc, margin/2, xc, svgHeight-margin/2))
+		}
+		grid = append(grid, fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none"/>`, margin/2, margin/2, svgWidth-margin, svgHeight-margin))
+	}
+	if len(grid) > 0 {
+		fmt.Fprintln(bw, `<g stroke="#999999" stroke-width="1">`)
+		for _, g := range grid {
+			fmt.Fprintln(bw, g)
+		}
+		fmt.Fprintln(bw, "</g>")
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
@@ -0,0 +1,82 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Table styles.
+
+A TableStyle picks the lines drawn around and through a table - which ones, and the characters they're drawn with -
+without having to know which Theme settings do what. WithTableStyle() sets a theme's ColumnSeparator, HeaderSeparator,
+Frame, and OpenFrame to match, leaving its padding and colors alone:
+
+	ct.Theme = ct.Theme.WithTableStyle(ctable.TableStyle{Border: "heavy", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true})
+
+	┏━━━━━━━━┳━━━━━━━━┓
+	┃ Name   ┃ Status ┃
+	┣━━━━━━━━╋━━━━━━━━┫
+	┃ node-1 ┃ Ready  ┃
+	┗━━━━━━━━┻━━━━━━━━┛
+
+The usual ones have names, see TableStyleByName() - "ascii", "light", "rounded", "heavy", "double" (everything drawn),
+"markdown" (a GitHub flavored Markdown table), and "borderless" (just a line under the header):
+
+	ts, _ := ctable.TableStyleByName("markdown")
+	ct.Theme = ct.Theme.WithTableStyle(ts)
+
+	| Name   | Status |
+	|--------|--------|
+	| node-1 | Ready  |
+*/
+
+type TableStyle struct {
+	Border       string // characters - "ascii", "light", "rounded", "heavy", "double", "markdown", "" for ascii
+	Edges        bool   // lines down the left and right sides
+	ColumnLines  bool   // lines between the columns
+	HeaderLine   bool   // line under the header
+	TopAndBottom bool   // lines across the top and bottom (of tables with Edges)
+}
+
+var tableStyles = map[string]TableStyle{
+	"ascii":      {Border: "ascii", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true},
+	"light":      {Border: "light", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true},
+	"rounded":    {Border: "rounded", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true},
+	"heavy":      {Border: "heavy", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true},
+	"double":     {Border: "double", Edges: true, ColumnLines: true, HeaderLine: true, TopAndBottom: true},
+	"markdown":   {Border: "markdown", Edges: true, ColumnLines: true, HeaderLine: true},
+	"borderless": {Border: "light", HeaderLine: true},
+}
+
+// TableStyleByName returns the table style called name ("ascii", "light", "rounded", "heavy", "double", "markdown", "borderless"),
+// ok is false if there's no such style.
+func TableStyleByName(name string) (ts TableStyle, ok bool) {
+	ts, ok = tableStyles[strings.ToLower(name)]
+	return ts, ok
+}
+
+// WithTableStyle returns the theme with its lines drawn as ts says (a Border that isn't known is drawn in ascii).
+func (th Theme) WithTableStyle(ts TableStyle) Theme {
+
+	border := strings.ToLower(ts.Border)
+	fr, ok := frames[border]
+	if !ok {
+		border, fr = "ascii", frames["ascii"]
+	}
+
+	th.ColumnSeparator, th.HeaderSeparator = "", ""
+	if ts.ColumnLines {
+		th.ColumnSeparator = fr.edge
+	}
+	if ts.HeaderLine {
+		th.HeaderSeparator = fr.rule.fill
+	}
+
+	th.Frame, th.OpenFrame, th.HeaderRule = "", false, ""
+	if ts.Edges {
+		th.Frame = border
+		th.OpenFrame = !ts.TopAndBottom
+	}
+
+	return th
+}
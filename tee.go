@@ -0,0 +1,41 @@
+package ctable
+
+import (
+	"io"
+)
+
+/*
+Rendering to several writers.
+
+RenderTo() displays the table to each of a number of writers in turn, e.g. to show it to the user and keep a copy in a
+log file in one call:
+
+	err := ct.RenderTo(os.Stdout, ctable.PlainWriter(logFile))
+
+Each writer gets what Display(true) would print to it - colors and styles on a terminal and plain text elsewhere (see
+color.go), unless the writer is wrapped with PlainWriter() (never any colors) or ColorWriter() (always colors, e.g. for
+a file to be viewed with less -R). The first error writing to any of the writers is returned, the others still get the table.
+*/
+
+// RenderTo displays the table, with headers, to each of writers.
+func (ct *Table) RenderTo(writers ...io.Writer) error {
+
+	var err error
+	for _, w := range writers {
+		if werr := ct.Fprint(w, true); err == nil {
+			err = werr
+		}
+	}
+
+	return err
+}
+
+// PlainWriter wraps w so output rendered to it (RenderTo(), Fprint()) has no colors or other ANSI styling.
+func PlainWriter(w io.Writer) io.Writer {
+	return &targetWriter{w: w, color: colorNever}
+}
+
+// ColorWriter wraps w so output rendered to it (RenderTo(), Fprint()) keeps its colors and styling, terminal or not.
+func ColorWriter(w io.Writer) io.Writer {
+	return &targetWriter{w: w, color: colorAlways}
+}
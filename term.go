@@ -0,0 +1,128 @@
+package ctable
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strconv"
+)
+
+/*
+Terminal handling shared by the interactive modes - key decoding and screen control sequences.
+Putting the terminal into raw mode and reading its size is platform specific, see term_unix.go / term_other.go.
+*/
+
+var errNotTerminal = errors.New("CONSOLETABLE: interactive mode requires a terminal")
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	clearLine   = "\x1b[2K"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	reverseOn   = "\x1b[7m"
+)
+
+// key codes for the non printable keys the interactive modes care about, printable keys come through as keyRune
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyInterrupt
+)
+
+type keyPress struct {
+	code int
+	r    rune
+}
+
+// readKey reads one key press from a terminal in raw mode
+func readKey(in *bufio.Reader) (keyPress, error) {
+
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return keyPress{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return keyPress{code: keyEnter}, nil
+	case 0x7f, 0x08:
+		return keyPress{code: keyBackspace}, nil
+	case 0x03, 0x04: // ctrl-c, ctrl-d (signals are off in raw mode)
+		return keyPress{code: keyInterrupt}, nil
+	case 0x1b:
+		// escape sequences arrive in one read, so if nothing else is buffered it was the escape key itself
+		if in.Buffered() == 0 {
+			return keyPress{code: keyEscape}, nil
+		}
+		return readEscapeSequence(in)
+	}
+
+	return keyPress{code: keyRune, r: r}, nil
+}
+
+// readEscapeSequence decodes the rest of a cursor key style sequence (ESC [ A, ESC [ 5 ~, ESC O H etc.)
+func readEscapeSequence(in *bufio.Reader) (keyPress, error) {
+
+	introducer, err := in.ReadByte()
+	if err != nil {
+		return keyPress{}, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return keyPress{code: keyEscape}, nil
+	}
+
+	param := ""
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return keyPress{}, err
+		}
+		if b >= '0' && b <= '9' || b == ';' {
+			param += string(b)
+			continue
+		}
+		switch {
+		case b == 'A':
+			return keyPress{code: keyUp}, nil
+		case b == 'B':
+			return keyPress{code: keyDown}, nil
+		case b == 'C':
+			return keyPress{code: keyRight}, nil
+		case b == 'D':
+			return keyPress{code: keyLeft}, nil
+		case b == 'H', b == '~' && (param == "1" || param == "7"):
+			return keyPress{code: keyHome}, nil
+		case b == 'F', b == '~' && (param == "4" || param == "8"):
+			return keyPress{code: keyEnd}, nil
+		case b == '~' && param == "5":
+			return keyPress{code: keyPageUp}, nil
+		case b == '~' && param == "6":
+			return keyPress{code: keyPageDown}, nil
+		}
+		// something we don't handle, swallow it
+		return keyPress{code: keyEscape}, nil
+	}
+}
+
+// terminalWidth returns the width of the terminal on stdout, falling back to $COLUMNS and then 80 when it isn't one
+func terminalWidth() int {
+
+	if width, _, err := terminalSize(os.Stdout); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 80
+}
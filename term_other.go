@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package ctable
+
+import "os"
+
+// interactive modes aren't supported on other platforms (yet)
+
+func isTerminal(f *os.File) bool {
+	return false
+}
+
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errNotTerminal
+}
+
+func terminalSize(f *os.File) (int, int, error) {
+	return 0, 0, errNotTerminal
+}
@@ -0,0 +1,65 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package ctable
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func ioctl(f *os.File, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	var t syscall.Termios
+	return ioctl(f, ioctlGetTermios, unsafe.Pointer(&t)) == nil
+}
+
+// makeRaw puts the terminal f into raw mode (no echo, no line buffering, no signals) and returns a func that restores the previous mode.
+// Output processing is left on so "\n" still starts a new line.
+func makeRaw(f *os.File) (func(), error) {
+
+	var old syscall.Termios
+	if err := ioctl(f, ioctlGetTermios, unsafe.Pointer(&old)); err != nil {
+		return nil, errNotTerminal
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(f, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ioctl(f, ioctlSetTermios, unsafe.Pointer(&old))
+	}, nil
+}
+
+// terminalSize returns the width and height (in characters) of the terminal f
+func terminalSize(f *os.File) (int, int, error) {
+
+	var ws struct {
+		Row    uint16
+		Col    uint16
+		Xpixel uint16
+		Ypixel uint16
+	}
+	if err := ioctl(f, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return 0, 0, errNotTerminal
+	}
+
+	return int(ws.Col), int(ws.Row), nil
+}
@@ -0,0 +1,142 @@
+package ctable
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Helpers for measuring, truncating, and padding field text.
+
+Field values can carry ANSI escape sequences (colors etc.), which take up no room on screen,
+so everything that deals in widths has to skip over them rather than just counting runes (and characters
+aren't all one column wide either, see width.go).
+*/
+
+// ansiSequenceLength returns the length in bytes of the ANSI escape (CSI) sequence at the start of s, or 0 if s doesn't start with one
+func ansiSequenceLength(s string) int {
+
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+
+	// parameter and intermediate bytes, then a single final byte in the range @ to ~
+	for i := 2; i < len(s); i++ {
+		if s[i] >= '@' && s[i] <= '~' {
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+// textWidth returns the number of characters s occupies on screen
+func textWidth(s string) int {
+
+	width := 0
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width += widthFunc(r)
+	}
+
+	return width
+}
+
+// truncateText cuts s down to n columns (a wide character that would go past n is left out), any styling that was cut off is reset so it doesn't bleed into what follows
+func truncateText(s string, n int) string {
+
+	var sb strings.Builder
+	styled := false
+	width := 0
+
+	for i := 0; i < len(s); {
+		if seqLen := ansiSequenceLength(s[i:]); seqLen > 0 {
+			sb.WriteString(s[i : i+seqLen])
+			styled = true
+			i += seqLen
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if width+widthFunc(r) > n {
+			break
+		}
+		sb.WriteString(s[i : i+size])
+		i += size
+		width += widthFunc(r)
+	}
+
+	if styled {
+		sb.WriteString(ansiReset)
+	}
+
+	return sb.String()
+}
+
+// padText pads s with spaces out to width characters - on the right for left justification, evenly either side for center
+// (any odd space going on the right), otherwise on the left
+func padText(s string, width int, justification Justification) string {
+	return fillText(s, width, justification, ' ')
+}
+
+// fillText is padText() padding with fill instead of spaces
+func fillText(s string, width int, justification Justification, fill rune) string {
+
+	padding := width - textWidth(s)
+	if padding <= 0 {
+		return s
+	}
+
+	pad := func(n int) string { return strings.Repeat(string(fill), n) }
+
+	switch justification {
+	case JustifyLeft:
+		return s + pad(padding)
+	case JustifyCenter:
+		return pad(padding/2) + s + pad(padding-padding/2)
+	}
+
+	return pad(padding) + s
+}
+
+// repeatToWidth repeats s as many times as needed to fill width characters
+func repeatToWidth(s string, width int) string {
+
+	if s == "" || width <= 0 {
+		return ""
+	}
+
+	return truncateText(strings.Repeat(s, width/textWidth(s)+1), width)
+}
+
+const ansiReset = "\x1b[0m"
+
+// colorize wraps s in the ANSI SGR sequence for code (e.g. "31" for red, "1;33" for bold yellow), an empty code leaves s as is
+func colorize(s string, code string) string {
+
+	if code == "" || s == "" {
+		return s
+	}
+
+	return "\x1b[" + code + "m" + s + ansiReset
+}
+
+// stripANSI returns s without any ANSI escape sequences
+func stripANSI(s string) string {
+
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,258 @@
+package ctable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*
+Themes.
+
+A Theme controls the look of a table independent of its data: what goes between columns, the line under the header,
+and colors. Themes can be loaded at run time from JSON or TOML (a flat subset - key = value pairs, optionally under a
+[theme] table), so end users can re-skin a tool's tables without it being recompiled, e.g.
+
+	# mytheme.toml
+	column_separator = "|"
+	padding = 1
+	header_separator = "-"
+	header_color = "1;36"
+*/
+
+type Theme struct {
+	ColumnSeparator string `json:"columnSeparator,omitempty"` // text between columns ("" for just padding)
+	Padding         int    `json:"padding"`                   // spaces either side of ColumnSeparator (or between columns when there's no separator)
+	HeaderSeparator string `json:"headerSeparator"`           // repeated under each column name ("" for no separator line)
+	HeaderColor     string `json:"headerColor,omitempty"`     // ANSI SGR code for the column names, e.g. "1" for bold
+	BorderColor     string `json:"borderColor,omitempty"`     // ANSI SGR code for the separators
+	SpaceHeader     bool   `json:"spaceHeader,omitempty"`     // blank line between the header and the rows
+	Frame           string `json:"frame,omitempty"`           // box around the table - "light", "rounded", "heavy", "double", "ascii", or "" for none (see frame.go)
+	HeaderRule      string `json:"headerRule,omitempty"`      // weight of the frame's rule under the header - "heavy" or "double", "" for the frame's own
+	OpenFrame       bool   `json:"openFrame,omitempty"`       // just the sides of the frame, no lines across the top and bottom
+	RowSeparator    string `json:"rowSeparator,omitempty"`    // repeated between rows ("" for nothing), framed tables use the frame's rule
+
+	// ANSI SGR codes for values by the type of their column (see InferTypes()), and for empty values - "" for none (see typecolor.go)
+	NumberColor string `json:"numberColor,omitempty"`
+	DateColor   string `json:"dateColor,omitempty"`
+	BoolColor   string `json:"boolColor,omitempty"`
+	NullColor   string `json:"nullColor,omitempty"`
+}
+
+// DefaultTheme is the classic look - columns a space apart, "=" under the column names, no colors.
+func DefaultTheme() Theme {
+	return Theme{
+		Padding:         1,
+		HeaderSeparator: "=",
+	}
+}
+
+// CompactTheme is for dense output where vertical space matters more than looks - two spaces between columns and
+// no line under the column names.
+func CompactTheme() Theme {
+	return Theme{
+		Padding: 2,
+	}
+}
+
+// SpaciousTheme is for reports read by people - wide gaps between columns and a blank line under the header.
+func SpaciousTheme() Theme {
+	return Theme{
+		Padding:         4,
+		HeaderSeparator: "-",
+		SpaceHeader:     true,
+	}
+}
+
+// RoundedTheme is a light frame with rounded corners and lines between the columns, the look of modern TUI panels.
+func RoundedTheme() Theme {
+	return Theme{
+		ColumnSeparator: "│",
+		Padding:         1,
+		HeaderSeparator: "─",
+		Frame:           "rounded",
+	}
+}
+
+// gap returns the text that goes between two columns, with separator (the theme's ColumnSeparator as drawn) colored if color is set
+// (the header lines are colored as a whole, so they take it uncolored)
+func (th Theme) gap(separator string, color bool) string {
+
+	if separator == "" {
+		return strings.Repeat(" ", th.Padding)
+	}
+
+	pad := strings.Repeat(" ", th.Padding)
+	if color {
+		return pad + colorize(separator, th.BorderColor) + pad
+	}
+	return pad + separator + pad
+}
+
+// named themes for ThemeByName() (and CTABLE_STYLE)
+var namedThemes = map[string]func() Theme{
+	"default":  DefaultTheme,
+	"compact":  CompactTheme,
+	"spacious": SpaciousTheme,
+	"rounded":  RoundedTheme,
+	"pipe": func() Theme {
+		return Theme{ColumnSeparator: "|", Padding: 1, HeaderSeparator: "-"}
+	},
+}
+
+// ThemeByName returns the built-in theme called name ("default", "compact", "spacious", "rounded", "pipe"), ok is false if there's no such theme.
+func ThemeByName(name string) (th Theme, ok bool) {
+
+	if fn, ok := namedThemes[strings.ToLower(name)]; ok {
+		return fn(), true
+	}
+
+	return Theme{}, false
+}
+
+// LoadTheme loads a theme from a file, TOML if the file name ends in .toml, JSON otherwise.
+// Settings the file doesn't mention keep their DefaultTheme() values.
+func LoadTheme(path string) (Theme, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return readThemeTOML(f)
+	}
+
+	return readThemeJSON(f)
+}
+
+// ReadTheme reads a theme from r, JSON if it starts with a '{', TOML otherwise.
+// Settings r doesn't mention keep their DefaultTheme() values.
+func ReadTheme(r io.Reader) (Theme, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return readThemeJSON(bytes.NewReader(data))
+	}
+
+	return readThemeTOML(bytes.NewReader(data))
+}
+
+func readThemeJSON(r io.Reader) (Theme, error) {
+	th := DefaultTheme()
+	err := json.NewDecoder(r).Decode(&th)
+	return th, err
+}
+
+// readThemeTOML reads the flat subset of TOML themes need - key = value lines (strings, integers, booleans),
+// comments, and table headers (which are ignored). Keys match the JSON names, case insensitive, with or without underscores.
+func readThemeTOML(r io.Reader) (Theme, error) {
+
+	th := DefaultTheme()
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return th, fmt.Errorf("CONSOLETABLE: theme line %d: expected key = value", lineNumber)
+		}
+		key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(line[:eq]), "_", ""))
+		value, err := tomlValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return th, fmt.Errorf("CONSOLETABLE: theme line %d: %v", lineNumber, err)
+		}
+
+		switch key {
+		case "columnseparator":
+			th.ColumnSeparator = value
+		case "headerseparator":
+			th.HeaderSeparator = value
+		case "headercolor":
+			th.HeaderColor = value
+		case "bordercolor":
+			th.BorderColor = value
+		case "frame":
+			th.Frame = value
+		case "headerrule":
+			th.HeaderRule = value
+		case "rowseparator":
+			th.RowSeparator = value
+		case "numbercolor":
+			th.NumberColor = value
+		case "datecolor":
+			th.DateColor = value
+		case "boolcolor":
+			th.BoolColor = value
+		case "nullcolor":
+			th.NullColor = value
+		case "openframe":
+			if th.OpenFrame, err = strconv.ParseBool(value); err != nil {
+				return th, fmt.Errorf("CONSOLETABLE: theme line %d: open_frame must be true or false", lineNumber)
+			}
+		case "spaceheader":
+			if th.SpaceHeader, err = strconv.ParseBool(value); err != nil {
+				return th, fmt.Errorf("CONSOLETABLE: theme line %d: space_header must be true or false", lineNumber)
+			}
+		case "padding":
+			if th.Padding, err = strconv.Atoi(value); err != nil {
+				return th, fmt.Errorf("CONSOLETABLE: theme line %d: padding must be a number", lineNumber)
+			}
+		default:
+			return th, fmt.Errorf("CONSOLETABLE: theme line %d: unknown setting %q", lineNumber, strings.TrimSpace(line[:eq]))
+		}
+	}
+
+	return th, scanner.Err()
+}
+
+// tomlValue returns a TOML value as a string - quoted strings are unquoted, anything else (numbers, booleans) is taken as is,
+// in both cases dropping any trailing comment
+func tomlValue(raw string) (string, error) {
+
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		// find the closing quote, skipping escaped ones
+		for i := 1; i < len(raw); i++ {
+			if raw[i] == '\\' {
+				i++
+				continue
+			}
+			if raw[i] == '"' {
+				return strconv.Unquote(raw[:i+1])
+			}
+		}
+		return "", fmt.Errorf("unterminated string")
+
+	case strings.HasPrefix(raw, "'"):
+		// literal string, no escapes
+		if end := strings.Index(raw[1:], "'"); end >= 0 {
+			return raw[1 : end+1], nil
+		}
+		return "", fmt.Errorf("unterminated string")
+	}
+
+	if hash := strings.Index(raw, "#"); hash >= 0 {
+		raw = raw[:hash]
+	}
+
+	return strings.TrimSpace(raw), nil
+}
@@ -0,0 +1,50 @@
+package ctable
+
+import (
+	"fmt"
+)
+
+/*
+Layout tracing.
+
+When a table renders unexpectedly (a column cut short, one far wider than its data) set Trace to a writer (or
+CTABLE_TRACE in the environment for stderr) and every layout pass reports, per column, the widest value measured,
+whether truncation kicked in, and the width the column finally got along with what decided it - the last layout step
+to change it (longest value, truncated, wrapped, masked, abbreviated, decimal alignment, sort indicator, min width,
+tree prefixes, locked, fitted to width, flush right, footer, widest while watching), e.g.
+
+	CONSOLETABLE trace: layout of 3 columns, 20 rows (24 lines), 61 wide
+	CONSOLETABLE trace:   1 "Name"      longest 34, truncate at 20 (truncating), allotted 23 - truncated
+	CONSOLETABLE trace:   2 "Size"      longest 6, allotted 9 - decimal alignment
+	CONSOLETABLE trace:   3 "Modified"  longest 19, allotted 16 - fitted to width
+*/
+
+// traceLayout writes the decisions behind lay to Trace
+func (ct *Table) traceLayout(lay layout) {
+
+	const prefix = "CONSOLETABLE trace: "
+
+	fmt.Fprintf(ct.Trace, "%slayout of %d columns, %d rows (%d lines), %d wide\n", prefix, ct.ColumnCount, lay.rows, lay.lines, lay.totalWidth())
+
+	nameWidth := 0
+	for _, col := range ct.Columns {
+		if w := textWidth(fmt.Sprintf("%q", col.Name)); w > nameWidth {
+			nameWidth = w
+		}
+	}
+
+	for i, col := range ct.Columns {
+		line := fmt.Sprintf("%s  %d %s  longest %d", prefix, i+1, padText(fmt.Sprintf("%q", col.Name), nameWidth, "left"), col.maxLength)
+		if col.truncateAt > 0 {
+			state := "not needed"
+			if col.truncationRequired {
+				state = "truncating"
+			}
+			line += fmt.Sprintf(", truncate at %d (%s)", col.truncateAt, state)
+		}
+		if col.MinWidth > 0 {
+			line += fmt.Sprintf(", min width %d", col.MinWidth)
+		}
+		fmt.Fprintf(ct.Trace, "%s, allotted %d - %s\n", line, lay.widths[i], lay.widthReasons[i])
+	}
+}
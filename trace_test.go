@@ -0,0 +1,65 @@
+package ctable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTraceWidthReasons(t *testing.T) {
+
+	newTable := func() *Table {
+		ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("Token", 0), NewColumn("Region", 0), NewColumn("Notes", 0)})
+		ct.Columns[1].Mask = "*"
+		ct.Abbreviate("Region", map[string]string{"us-east-1": "use1"})
+		ct.AddRow("web-1", "secret", "us-east-1", "a long note that won't fit")
+		return &ct
+	}
+
+	tests := []struct {
+		name  string
+		setup func(ct *Table)
+		want  []string
+	}{
+		{
+			name:  "sized to values",
+			setup: func(ct *Table) {
+				ct.SortIndicators = true
+				ct.SortBy("Name", true)
+			},
+			want: []string{
+				`"Name"    longest 5, allotted 6 - sort indicator`,
+				`"Token"   longest 6, allotted 8 - masked`,
+				`"Region"  longest 9, allotted 6 - abbreviated`,
+				`"Notes"   longest 26, allotted 26 - longest value`,
+			},
+		},
+		{
+			name:  "locked",
+			setup: func(ct *Table) { ct.SetColumnWidths(8, 0, 0, 0) },
+			want:  []string{`"Name"    longest 5, allotted 8 - locked`},
+		},
+		{
+			name: "fitted",
+			setup: func(ct *Table) {
+				ct.FitToWidth = true
+				ct.MaxWidth = 30
+			},
+			want: []string{`"Notes"   longest 26, allotted 13 - fitted to width`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := newTable()
+			tt.setup(ct)
+			var trace strings.Builder
+			ct.Trace = &trace
+			_ = ct.String()
+			for _, want := range tt.want {
+				if !strings.Contains(trace.String(), want) {
+					t.Errorf("trace is missing %q:\n%s", want, trace.String())
+				}
+			}
+		})
+	}
+}
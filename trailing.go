@@ -0,0 +1,30 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Trailing padding.
+
+Display() doesn't pad the last column out to its width - the spaces after the last value on a line are left off, so
+output pasted into an issue or kept under version control doesn't carry trailing whitespace (and diffs of it don't
+light up). The columns still line up, nothing follows the last one.
+
+The padding is kept where something does follow it: with LineSuffix set, inside a frame (the frame pads the lines
+out to its right side), and on styled lines, where the style (a background color say) should run the width of the
+table. PadLastColumn puts it back everywhere, for callers that rely on every line being the same length.
+*/
+
+// trimmedLine returns s (display line l, or the header for l < 0) without the spaces at the end, unless they're wanted
+func (ct *Table) trimmedLine(l int, s string, lay layout) string {
+
+	if ct.PadLastColumn || ct.LineSuffix != "" {
+		return s
+	}
+	if l >= 0 && lay.lineStyles != nil && lay.lineStyles[l].code() != "" {
+		return s
+	}
+
+	return strings.TrimRight(s, " ")
+}
@@ -0,0 +1,176 @@
+package ctable
+
+import (
+	"fmt"
+)
+
+/*
+Hierarchical (tree) rows.
+
+Rows added with AddChildRow() hang off a parent row. On display every child is listed directly under its parent
+(after any earlier children of the same parent), so a parent's whole subtree stays together, and the first column
+is indented with tree guides:
+
+	web
+	├─ nginx
+	│  └─ openssl
+	└─ app
+
+Rows added with AddRow() are roots. Tables without any child rows display in the order rows were added, as always.
+
+A parent row and its subtree form a group that can be collapsed (Collapse(), CollapseAll()) so it displays as
+a single summary line instead, e.g. "▶ web-tier (12 rows)", until expanded again (Expand(), ExpandAll(), or + in the viewer).
+*/
+
+// tree guide pieces, box drawing by default or ASCII (Table.ASCII)
+type guideSet struct {
+	branch, last, stem, collapsed string
+}
+
+var (
+	treeGuides      = guideSet{branch: "├─ ", last: "└─ ", stem: "│  ", collapsed: "▶"}
+	asciiTreeGuides = guideSet{branch: "|- ", last: "`- ", stem: "|  ", collapsed: ">"}
+)
+
+// AddChildRow adds a row as a child of row parent (zero based, in the order rows were added) and returns the new row's index,
+// -1 if the row couldn't be added.
+func (ct *Table) AddChildRow(parent int, fields ...interface{}) int {
+
+	if parent < 0 || parent >= ct.RowCount {
+		ct.fatal("AddChildRow() parent row index is out of range.")
+		return -1
+	}
+
+	// (a Logger whose Fatal() returns leaves the row out, see logger.go)
+	rows := ct.RowCount
+	ct.AddRow(fields...)
+	if ct.RowCount == rows {
+		return -1
+	}
+
+	// parent tracking only starts with the first child row, everything added before that is a root
+	if ct.parents == nil {
+		ct.parents = make([]int, ct.RowCount)
+		for i := range ct.parents {
+			ct.parents[i] = -1
+		}
+	}
+	ct.parents[ct.RowCount-1] = parent
+
+	return ct.RowCount - 1
+}
+
+// Collapse collapses the group made up of row parent and its subtree into a single summary line.
+func (ct *Table) Collapse(parent int) {
+
+	if ct.collapsed == nil {
+		ct.collapsed = map[int]bool{}
+	}
+	ct.collapsed[parent] = true
+	ct.windowLayout = nil
+}
+
+// Expand undoes Collapse() for row parent.
+func (ct *Table) Expand(parent int) {
+	delete(ct.collapsed, parent)
+	ct.windowLayout = nil
+}
+
+// CollapseAll collapses every group (every row with child rows).
+func (ct *Table) CollapseAll() {
+	for _, parent := range ct.parents {
+		if parent >= 0 {
+			ct.Collapse(parent)
+		}
+	}
+}
+
+// ExpandAll expands every collapsed group.
+func (ct *Table) ExpandAll() {
+	ct.collapsed = nil
+	ct.windowLayout = nil
+}
+
+// treeLayout works out the display order of the rows, the guide prefix for the first column of every display line,
+// and the summary lines of collapsed groups (both indexed by storage line) - nothing is set when there are no child rows
+func (ct *Table) treeLayout(lay *layout) {
+
+	if ct.parents == nil {
+		return
+	}
+
+	children := make([][]int, ct.RowCount)
+	roots := []int{}
+	for _, r := range ct.sortedRows() {
+		if parent := ct.parents[r]; parent < 0 {
+			roots = append(roots, r)
+		} else {
+			children[parent] = append(children[parent], r)
+		}
+	}
+
+	lay.prefixes = make([]string, ct.lineCount())
+	lay.stems = make([]string, ct.RowCount)
+
+	// number of rows in the subtree under r
+	var descendants func(r int) int
+	descendants = func(r int) int {
+		n := len(children[r])
+		for _, child := range children[r] {
+			n += descendants(child)
+		}
+		return n
+	}
+
+	guides := treeGuides
+	if ct.ASCII {
+		guides = asciiTreeGuides
+	}
+
+	// stem is the guide text carried down from the ancestors ("│  " or "   " per level)
+	var walk func(r int, stem string, depth int, last bool)
+	walk = func(r int, stem string, depth int, last bool) {
+
+		lay.rowOrder = append(lay.rowOrder, r)
+
+		branch, childStem := "", ""
+		if depth > 0 {
+			if last {
+				branch, childStem = stem+guides.last, stem+"   "
+			} else {
+				branch, childStem = stem+guides.branch, stem+guides.stem
+			}
+		}
+
+		first, end := ct.rowLines(r)
+		lay.prefixes[first] = branch
+
+		// a collapsed group is just the one summary line, labeled with the parent's first field
+		if ct.collapsed[r] && len(children[r]) > 0 {
+			if lay.summaries == nil {
+				lay.summaries = map[int]string{}
+			}
+			n := descendants(r)
+			plural := "s"
+			if n == 1 {
+				plural = ""
+			}
+			lay.summaries[first] = fmt.Sprintf("%s %s (%d row%s)", guides.collapsed, ct.displayedCell(first, 0), n, plural)
+			return
+		}
+
+		// continuation lines of a multiline row just carry the guides on down
+		lay.stems[r] = childStem
+		for l := first + 1; l < end; l++ {
+			lay.prefixes[l] = childStem
+		}
+
+		for i, child := range children[r] {
+			walk(child, childStem, depth+1, i == len(children[r])-1)
+		}
+	}
+
+	for _, r := range roots {
+		walk(r, "", 0, false)
+	}
+}
@@ -0,0 +1,65 @@
+package ctable
+
+/*
+Truncation within the column width.
+
+A value cut short at a column's truncateAt gets "..." added after it, so a column truncated at 20 takes up 23 characters.
+With TruncateWithinWidth set the "..." comes out of the 20 instead - 17 characters of the value and the "..." - so
+truncateAt is the width the column is displayed at, as set. (Columns truncated at 3 characters or fewer have no room for
+the "...", their values are just cut.)
+
+Values too wide for a width settled before they were seen (DisplaySource(), RenderRows()), or for a column FitToWidth
+narrowed, are cut short the same way, the "..." coming out of the column's width.
+*/
+
+const truncationDots = "..."
+
+// truncated returns s (too wide for column c) cut short per the column's truncateAt
+func (ct *Table) truncated(c int, s string) string {
+	return truncateText(s, ct.truncatedShown(c)) + ct.truncationMark(c)
+}
+
+// truncatedShown returns how many characters of a value cut short in column c are shown
+func (ct *Table) truncatedShown(c int) int {
+	return ct.truncatedWidth(c) - len(ct.truncationMark(c))
+}
+
+// truncatedWidth returns the width a value cut short in column c takes up, "..." included
+func (ct *Table) truncatedWidth(c int) int {
+
+	if ct.TruncateWithinWidth {
+		return ct.Columns[c].truncateAt
+	}
+
+	return ct.Columns[c].truncateAt + len(truncationDots)
+}
+
+// clipped returns s cut short to width characters for column c, the last of them the truncation mark when there's room for it
+func (ct *Table) clipped(c int, s string, width int) string {
+
+	if shown := ct.clippedShown(c, width); shown < width {
+		return truncateText(s, shown) + ct.truncationMark(c)
+	}
+
+	return truncateText(s, width)
+}
+
+// clippedShown returns how many characters of a value clipped() to width in column c are shown
+func (ct *Table) clippedShown(c int, width int) int {
+
+	if mark := len(ct.truncationMark(c)); width > mark {
+		return width - mark
+	}
+
+	return width
+}
+
+// truncationMark returns what's put after a value cut short in column c
+func (ct *Table) truncationMark(c int) string {
+
+	if ct.TruncateWithinWidth && ct.Columns[c].truncateAt <= len(truncationDots) {
+		return ""
+	}
+
+	return truncationDots
+}
@@ -0,0 +1,67 @@
+package ctable
+
+import (
+	"strings"
+)
+
+/*
+Colors by type.
+
+Once InferTypes() has worked out what's in each column, the theme can color values by type - numbers, dates, and
+booleans each their own color, and empty values (blank, or EmptyValue) another - so a wide table is easier to scan.
+It's opt in, the colors are the theme's NumberColor, DateColor, BoolColor, and NullColor (set in code, or in a theme
+file like any other setting), WithTypeColors() fills in a subtle set:
+
+	ct.InferTypes()
+	ct.Theme = ct.Theme.WithTypeColors()
+
+The colors go on the values as displayed (after any truncation), not the padding, and give way to errors colored with
+ErrorColor and to styled values generally.
+*/
+
+// WithTypeColors returns the theme with the default colors by type filled in - numbers cyan, dates magenta,
+// booleans yellow, and empty values dim. Colors the theme already has are kept.
+func (th Theme) WithTypeColors() Theme {
+
+	set := func(color *string, code string) {
+		if *color == "" {
+			*color = code
+		}
+	}
+	set(&th.NumberColor, "36")
+	set(&th.DateColor, "35")
+	set(&th.BoolColor, "33")
+	set(&th.NullColor, "2")
+
+	return th
+}
+
+// typeColored returns value (field c of display line l, as it's to be displayed) in the theme's color for its type
+func (ct *Table) typeColored(l int, c int, value string) string {
+
+	th := ct.Theme
+	if th.NumberColor == "" && th.DateColor == "" && th.BoolColor == "" && th.NullColor == "" {
+		return value
+	}
+
+	stored := ct.cell(l, c)
+	if strings.Contains(stored, "\x1b[") {
+		return value
+	}
+
+	// (blank values come out blank whatever the color, it's for EmptyValue placeholders)
+	if stored = strings.TrimSpace(stored); stored == "" || stored == ct.EmptyValue {
+		return colorize(value, th.NullColor)
+	}
+
+	switch ct.Columns[c].Type {
+	case "number":
+		return colorize(value, th.NumberColor)
+	case "date":
+		return colorize(value, th.DateColor)
+	case "bool":
+		return colorize(value, th.BoolColor)
+	}
+
+	return value
+}
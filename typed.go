@@ -0,0 +1,65 @@
+package ctable
+
+/*
+Generic (typed) tables.
+
+A TypedTable is a Table whose columns are defined with extractor funcs that pull each column's value out of a T,
+so rows are added as a T rather than as a list of fields - the compiler catches missing or mis-ordered fields
+instead of AddRow() failing at run time.
+
+Example:
+	tt := ctable.NewTypedTable([]ctable.TypedColumn[Host]{
+		ctable.NewTypedColumn("Name", 0, func(h Host) string { return h.Name }),
+		ctable.NewTypedColumn("Address", 0, func(h Host) string { return h.Addr.String() }),
+	})
+	tt.AddRow(host)
+	tt.Display(true)
+
+(named TypedTable rather than Table[T] since Table is already taken by the untyped table)
+*/
+
+// TypedColumn is a column definition plus the func used to extract the column's value from a T.
+type TypedColumn[T any] struct {
+	Column
+	Value func(T) string
+}
+
+func NewTypedColumn[T any](name string, truncateAt int, value func(T) string) TypedColumn[T] {
+	return TypedColumn[T]{
+		Column: NewColumn(name, truncateAt),
+		Value:  value,
+	}
+}
+
+// TypedTable embeds a Table, so everything other than adding rows (display, callbacks etc.) works the same.
+type TypedTable[T any] struct {
+	Table
+	extractors []func(T) string
+}
+
+func NewTypedTable[T any](columns []TypedColumn[T]) TypedTable[T] {
+
+	cols := make([]Column, len(columns))
+	extractors := make([]func(T) string, len(columns))
+
+	for i, c := range columns {
+		cols[i] = c.Column
+		extractors[i] = c.Value
+	}
+
+	return TypedTable[T]{
+		Table:      NewTable(cols),
+		extractors: extractors,
+	}
+}
+
+// AddRow adds one row, running each column's extractor against item to get the field values.
+func (tt *TypedTable[T]) AddRow(item T) {
+
+	fields := make([]interface{}, len(tt.extractors))
+	for i, value := range tt.extractors {
+		fields[i] = value(item)
+	}
+
+	tt.Table.AddRow(fields...)
+}
@@ -0,0 +1,415 @@
+package ctable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Interactive viewer.
+
+View() takes over the terminal and shows the table full screen, built on the same layout and line formatting as Display(),
+so the columns look exactly as they would printed (non-interactive output is unaffected).
+
+Tables wider than the terminal scroll horizontally a column at a time. ViewFrozen() pins a key column on the left
+so it stays on screen while the rest scroll past it.
+
+Keys:
+	up/down, k/j       scroll a line
+	left/right, h/l    scroll a column
+	pgup/pgdn, space   scroll a page
+	home/end, g/G      jump to the top/bottom
+	1-9                sort by that column (counting the columns shown), pressing the same number again reverses the order
+	0                  back to the original order
+	+/-                expand/collapse all groups (tree rows), for the viewer only - the table is left as it was
+	/                  filter - type text to show only rows containing it (case insensitive), enter keeps it, esc clears it
+	q, esc             quit
+*/
+
+// View runs the interactive viewer on the terminal (stdin/stdout) until the user quits.
+func (ct *Table) View() error {
+	return newViewer(ct).runTerminal()
+}
+
+// ViewFrozen runs the interactive viewer with the named column pinned on the left while the other columns scroll horizontally.
+func (ct *Table) ViewFrozen(column string) error {
+
+	frozen := ct.columnIndex(column)
+	if frozen < 0 {
+		return fmt.Errorf("CONSOLETABLE: no column named %q", column)
+	}
+
+	v := newViewer(ct)
+	v.frozen = frozen
+	return v.runTerminal()
+}
+
+type viewer struct {
+	ct  *Table
+	lay layout
+
+	rows     []int // rows shown, in the order shown (after filtering and sorting)
+	lines    []int // display lines of those rows, in the order shown
+	top      int   // index into lines of the first line on screen
+	sortCol  int   // -1 when in the original order
+	sortDesc bool
+	filter   string
+
+	filtering bool // currently typing a filter
+
+	frozen  int // column pinned on the left, -1 for none
+	leftCol int // how many of the other columns are scrolled off to the left
+}
+
+func newViewer(ct *Table) *viewer {
+	v := &viewer{
+		ct:      ct,
+		lay:     ct.computeLayout(),
+		sortCol: -1,
+		frozen:  -1,
+	}
+	v.refresh()
+	return v
+}
+
+// refresh works out which rows are shown, and in what order, from the current filter and sort settings
+func (v *viewer) refresh() {
+
+	v.rows = v.rows[:0]
+	needle := strings.ToLower(v.filter)
+
+	for i := 0; i < v.lay.rows; i++ {
+		if r := v.lay.rowAt(i); needle == "" || v.rowContains(r, needle) {
+			v.rows = append(v.rows, r)
+		}
+	}
+
+	if v.sortCol >= 0 {
+		sort.SliceStable(v.rows, func(a, b int) bool {
+			va := v.ct.cell(v.ct.rowStarts[v.rows[a]], v.sortCol)
+			vb := v.ct.cell(v.ct.rowStarts[v.rows[b]], v.sortCol)
+			return compareSorted(v.ct.Columns[v.sortCol].SortAs, va, vb, v.sortDesc) < 0
+		})
+	}
+
+	v.lines = v.lines[:0]
+	for _, r := range v.rows {
+		v.lines = v.ct.appendRowLines(v.lines, v.lay, r)
+	}
+
+	// ditto marks follow the rows as shown
+	v.ct.dittoLayout(&v.lay, v.rows)
+}
+
+// rowContains reports whether any field of row i contains needle (already lower cased)
+func (v *viewer) rowContains(i int, needle string) bool {
+
+	first, end := v.ct.rowLines(i)
+	for l := first; l < end; l++ {
+		for c := 0; c < v.ct.ColumnCount; c++ {
+			if strings.Contains(strings.ToLower(v.ct.displayedCell(l, c)), needle) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pageHeight is the number of table lines that fit on screen under the header and above the status line
+func pageHeight(height int) int {
+	if height < 4 {
+		return 1
+	}
+	return height - 3
+}
+
+// scroll moves the view by delta lines, keeping it within the table
+func (v *viewer) scroll(delta int, height int) {
+
+	v.top += delta
+
+	if last := len(v.lines) - pageHeight(height); v.top > last {
+		v.top = last
+	}
+	if v.top < 0 {
+		v.top = 0
+	}
+}
+
+func (v *viewer) draw(out io.Writer, width int, height int) {
+
+	var sb strings.Builder
+	sb.WriteString(clearScreen)
+
+	header, separator := v.header()
+	sb.WriteString(truncateText(header, width) + "\n")
+	sb.WriteString(truncateText(separator, width) + "\n")
+
+	for i := v.top; i < v.top+pageHeight(height) && i < len(v.lines); i++ {
+		sb.WriteString(truncateText(v.line(v.lines[i]), width) + "\n")
+	}
+
+	sb.WriteString(reverseOn + truncateText(padText(v.status(height), width, "left"), width) + ansiReset)
+	io.WriteString(out, sb.String())
+}
+
+func (v *viewer) status(height int) string {
+
+	if v.filtering {
+		return "/" + v.filter
+	}
+
+	last := v.top + pageHeight(height)
+	if last > len(v.lines) {
+		last = len(v.lines)
+	}
+
+	status := fmt.Sprintf(" lines %d-%d of %d, %d of %d rows", v.top+1, last, len(v.lines), len(v.rows), v.ct.RowCount)
+	if v.sortCol >= 0 {
+		direction := "asc"
+		if v.sortDesc {
+			direction = "desc"
+		}
+		status += fmt.Sprintf(" | sort: %s %s", v.ct.Columns[v.sortCol].Name, direction)
+	}
+	if v.filter != "" {
+		status += " | filter: " + v.filter
+	}
+	if v.leftCol > 0 {
+		status += fmt.Sprintf(" | scrolled %d columns", v.leftCol)
+	}
+
+	return status + " | q quit, / filter, 1-9 sort "
+}
+
+// columns returns the columns on screen, in order - the frozen column then the others from leftCol on
+func (v *viewer) columns() []int {
+
+	cols := []int{}
+	if v.frozen >= 0 {
+		cols = append(cols, v.frozen)
+	}
+
+	n := 0
+	for _, c := range v.lay.columns() {
+		if c == v.frozen {
+			continue
+		}
+		if n >= v.leftCol {
+			cols = append(cols, c)
+		}
+		n++
+	}
+
+	return cols
+}
+
+// gap returns the text that goes in front of the column in position i of the on screen columns (header lines take it uncolored)
+func (v *viewer) gap(i int, header bool) string {
+	switch {
+	case i == 0:
+		return ""
+	case i == 1 && v.frozen >= 0 && v.ct.ASCII:
+		return " | "
+	case i == 1 && v.frozen >= 0:
+		return " │ "
+	case header:
+		return v.lay.headerGap
+	}
+	return v.lay.gap
+}
+
+// line formats display line l with just the columns on screen
+func (v *viewer) line(l int) string {
+
+	if _, ok := v.lay.summaries[l]; ok {
+		return v.ct.formatLine(l, v.lay)
+	}
+
+	line := ""
+	for i, c := range v.columns() {
+		line += v.gap(i, false) + v.ct.formatField(l, c, v.lay)
+	}
+
+	return v.lay.styleLine(l, line)
+}
+
+func (v *viewer) header() (string, string) {
+
+	header, separator := "", ""
+	for i, c := range v.columns() {
+		name, sep := v.ct.formatHeaderField(c, v.lay)
+		header += v.gap(i, true) + name
+		separator += strings.NewReplacer("│", "┼", "|", "+").Replace(v.gap(i, true)) + sep
+	}
+
+	return colorize(header, v.ct.Theme.HeaderColor), colorize(separator, v.ct.Theme.BorderColor)
+}
+
+// scrollColumns moves the view horizontally by delta columns
+func (v *viewer) scrollColumns(delta int) {
+
+	scrollable := v.ct.ColumnCount - 1
+	if v.frozen < 0 {
+		scrollable = v.ct.ColumnCount
+	}
+
+	v.leftCol += delta
+	if v.leftCol > scrollable-1 {
+		v.leftCol = scrollable - 1
+	}
+	if v.leftCol < 0 {
+		v.leftCol = 0
+	}
+}
+
+// sortBy handles a sort key press for the column shown in position n, counting from 1 (0 meaning back to the original order)
+func (v *viewer) sortBy(n int) {
+
+	cols := v.lay.columns()
+
+	switch {
+	case n == 0:
+		v.sortCol = -1
+	case n > len(cols):
+		return
+	case v.sortCol == cols[n-1]:
+		v.sortDesc = !v.sortDesc
+	default:
+		v.sortCol = cols[n-1]
+		v.sortDesc = false
+	}
+
+	v.refresh()
+}
+
+// runTerminal takes over the terminal (stdin/stdout) for the viewer, putting it back the way it was afterwards
+func (v *viewer) runTerminal() error {
+
+	if !isTerminal(os.Stdout) {
+		return errNotTerminal
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor + clearScreen)
+
+	return v.run(bufio.NewReader(os.Stdin), os.Stdout)
+}
+
+func (v *viewer) run(in *bufio.Reader, out *os.File) error {
+
+	// +/- expand and collapse groups in the viewer only, the table's left as it was
+	defer v.restoreCollapsed(v.ct.collapsed)
+	v.ct.collapsed = copyCollapsed(v.ct.collapsed)
+
+	for {
+		width, height, err := terminalSize(out)
+		if err != nil {
+			return err
+		}
+
+		v.draw(out, width, height)
+
+		key, err := readKey(in)
+		if err != nil {
+			return err
+		}
+
+		if v.filtering {
+			v.filterKey(key)
+			continue
+		}
+
+		switch {
+		case key.code == keyInterrupt, key.code == keyEscape, key.code == keyRune && key.r == 'q':
+			return nil
+		case key.code == keyUp, key.code == keyRune && key.r == 'k':
+			v.scroll(-1, height)
+		case key.code == keyDown, key.code == keyEnter, key.code == keyRune && key.r == 'j':
+			v.scroll(1, height)
+		case key.code == keyLeft, key.code == keyRune && key.r == 'h':
+			v.scrollColumns(-1)
+		case key.code == keyRight, key.code == keyRune && key.r == 'l':
+			v.scrollColumns(1)
+		case key.code == keyPageUp, key.code == keyRune && key.r == 'b':
+			v.scroll(-pageHeight(height), height)
+		case key.code == keyPageDown, key.code == keyRune && key.r == ' ':
+			v.scroll(pageHeight(height), height)
+		case key.code == keyHome, key.code == keyRune && key.r == 'g':
+			v.top = 0
+		case key.code == keyEnd, key.code == keyRune && key.r == 'G':
+			v.scroll(len(v.lines), height)
+		case key.code == keyRune && key.r == '/':
+			v.filtering = true
+		case key.code == keyRune && (key.r == '+' || key.r == '-'):
+			if key.r == '+' {
+				v.ct.ExpandAll()
+			} else {
+				v.ct.CollapseAll()
+			}
+			v.lay = v.ct.computeLayout()
+			v.refresh()
+			v.scroll(0, height)
+		case key.code == keyRune && key.r >= '0' && key.r <= '9':
+			c, _ := strconv.Atoi(string(key.r))
+			v.sortBy(c)
+		}
+	}
+}
+
+// restoreCollapsed puts back the table's collapsed groups from before the viewer changed them
+func (v *viewer) restoreCollapsed(collapsed map[int]bool) {
+	v.ct.collapsed = collapsed
+	v.ct.windowLayout = nil
+}
+
+// copyCollapsed returns a copy of collapsed, nil if it's nil
+func copyCollapsed(collapsed map[int]bool) map[int]bool {
+
+	if collapsed == nil {
+		return nil
+	}
+
+	cp := make(map[int]bool, len(collapsed))
+	for parent := range collapsed {
+		cp[parent] = true
+	}
+
+	return cp
+}
+
+// filterKey handles a key press while the filter is being typed, the filter is applied as it's typed
+func (v *viewer) filterKey(key keyPress) {
+
+	switch key.code {
+	case keyEnter:
+		v.filtering = false
+		return
+	case keyEscape, keyInterrupt:
+		v.filtering = false
+		v.filter = ""
+	case keyBackspace:
+		if r := []rune(v.filter); len(r) > 0 {
+			v.filter = string(r[:len(r)-1])
+		}
+	case keyRune:
+		v.filter += string(key.r)
+	default:
+		return
+	}
+
+	v.top = 0
+	v.refresh()
+}
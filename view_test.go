@@ -0,0 +1,32 @@
+package ctable
+
+import (
+	"testing"
+)
+
+func TestViewerSortKeys(t *testing.T) {
+
+	ct := NewTable([]Column{NewColumn("Name", 0), NewColumn("Unused", 0), NewColumn("Size", 0)})
+	ct.HideEmptyColumns = true
+	ct.AddRow("b", "", 1)
+	ct.AddRow("a", "", 2)
+
+	// with Unused hidden, 2 is the second column shown
+	v := newViewer(&ct)
+	v.sortBy(2)
+	if v.sortCol != 2 || v.sortDesc {
+		t.Fatalf("2 sorted by column %d (descending %v), want Size ascending", v.sortCol, v.sortDesc)
+	}
+	v.sortBy(2)
+	if v.sortCol != 2 || !v.sortDesc {
+		t.Fatalf("2 again sorted by column %d (descending %v), want Size descending", v.sortCol, v.sortDesc)
+	}
+	if v.rows[0] != 1 {
+		t.Errorf("first row shown is %d, want 1", v.rows[0])
+	}
+
+	v.sortBy(3)
+	if v.sortCol != 2 {
+		t.Errorf("3 (past the columns shown) changed the sort to column %d", v.sortCol)
+	}
+}
@@ -0,0 +1,112 @@
+package ctable
+
+import (
+	"fmt"
+)
+
+/*
+Lossy output warnings.
+
+Truncation keeps tables readable but hides data. Warnings() lists every value the last Display() (or DisplayPaged())
+didn't show as it is, so a report can own up to it - values cut short (at truncateAt, TruncateWithinWidth or not, to
+a column FitToWidth narrowed, or to widths fixed up front), and values wrapped onto more lines (Column.Wrap):
+
+	ct.Display(true)
+	if w := ct.Warnings(); len(w) > 0 {
+		fmt.Printf("%d values truncated, use --wide for full output\n", len(w))
+	}
+*/
+
+// kinds of Warning
+const (
+	WarningTruncated = "truncated" // cut short, Shown characters of it displayed
+	WarningWrapped   = "wrapped"   // wrapped onto lines Shown characters wide, all of it displayed
+)
+
+// Warning describes a value that wasn't displayed as it is.
+type Warning struct {
+	Row    int    // row index (zero based, in the order rows were added), -1 for the header
+	Line   int    // line of the row the value is on (rows with multiline values have several), 0 for the first
+	Column int    // column index
+	Kind   string // what happened to the value - WarningTruncated or WarningWrapped
+	Width  int    // full width of the value
+	Shown  int    // how much of it was shown, the width of the lines it was wrapped onto when wrapped
+}
+
+func (w Warning) String() string {
+
+	where := fmt.Sprintf("row %d", w.Row+1)
+	if w.Row < 0 {
+		where = "header"
+	} else if w.Line > 0 {
+		where += fmt.Sprintf(" line %d", w.Line+1)
+	}
+
+	if w.Kind == WarningWrapped {
+		return fmt.Sprintf("%s, column %d: %s (%d characters on lines of %d)", where, w.Column+1, w.Kind, w.Width, w.Shown)
+	}
+
+	return fmt.Sprintf("%s, column %d: %s (%d of %d characters shown)", where, w.Column+1, w.Kind, w.Shown, w.Width)
+}
+
+// Warnings returns the values the last Display() or DisplayPaged() didn't show as they are, nil if there weren't any.
+func (ct *Table) Warnings() []Warning {
+	return ct.warnings
+}
+
+// recordWarnings works out which values displaying lay cuts short or wraps and keeps them for Warnings()
+func (ct *Table) recordWarnings(lay layout) {
+
+	ct.warnings = nil
+
+	for i, col := range ct.Columns {
+		width, shown := textWidth(col.Name), textWidth(col.Name)
+		if col.truncationRequired && width > col.truncateAt {
+			shown = ct.truncatedShown(i)
+		}
+		name := ct.headerName(i) + ct.sortMark(i)
+		if clipped := ct.clippedShown(i, lay.widths[i]); lay.fixed && textWidth(name) > lay.widths[i] && clipped < shown {
+			shown = clipped
+		}
+		if shown < width {
+			ct.warnings = append(ct.warnings, Warning{Row: -1, Column: i, Kind: WarningTruncated, Width: width, Shown: shown})
+		}
+	}
+
+	for pos := 0; pos < lay.rows; pos++ {
+		r := lay.rowAt(pos)
+		first, end := ct.rowLines(r)
+		if _, ok := lay.summaries[first]; ok {
+			continue
+		}
+		for l := first; l < end; l++ {
+			for i := range ct.Columns {
+				if _, ok := lay.wrapped[l*ct.ColumnCount+i]; ok {
+					if width, room := textWidth(ct.displayedCell(l, i)), lay.fieldRoom(l, i); width > room {
+						ct.warnings = append(ct.warnings, Warning{Row: r, Line: l - first, Column: i, Kind: WarningWrapped, Width: width, Shown: room})
+					}
+				} else if width, shown := ct.shownWidth(l, i, lay); shown < width {
+					ct.warnings = append(ct.warnings, Warning{Row: r, Line: l - first, Column: i, Kind: WarningTruncated, Width: width, Shown: shown})
+				}
+			}
+		}
+	}
+}
+
+// shownWidth returns the width of field i of display line l, and how much of it lay shows - formatField() cuts values
+// short at the column's truncateAt, and then to the column's width when that's fixed
+func (ct *Table) shownWidth(l int, i int, lay layout) (width int, shown int) {
+
+	col := ct.Columns[i]
+	width = textWidth(ct.fieldValue(l, i, lay))
+	shown, displayed := width, width
+
+	if col.truncationRequired && !col.Wrap && width > col.truncateAt {
+		shown, displayed = ct.truncatedShown(i), ct.truncatedWidth(i)
+	}
+	if room := lay.fieldRoom(l, i); lay.fixed && displayed > room && ct.clippedShown(i, room) < shown {
+		shown = ct.clippedShown(i, room)
+	}
+
+	return width, shown
+}
@@ -0,0 +1,65 @@
+package ctable
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+/*
+Watch mode.
+
+Watch() keeps a table on screen up to date, like watch(1) or kubectl get -w: every interval the rows are cleared,
+the refresh function fills the table again, and the table is redrawn in place, e.g.
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ct.Watch(ctx, 2*time.Second, func(ct *ctable.Table) {
+		for _, p := range listProcesses() {
+			ct.AddRow(p.Name, p.CPU, p.Memory)
+		}
+	})
+
+Columns only ever get wider while watching, never narrower, so the table doesn't jitter as values come and go.
+When output isn't a terminal the screen isn't cleared, each refresh is printed after the last.
+An interval of 0 or less refreshes every 2 seconds, the watch(1) default.
+*/
+
+// how often Watch() refreshes when it isn't given an interval
+const defaultWatchInterval = 2 * time.Second
+
+// Watch refreshes and redraws the table every interval until ctx is done, refresh is called with the table emptied of rows
+// to add the current ones. The first refresh is straight away.
+func (ct *Table) Watch(ctx context.Context, interval time.Duration, refresh func(*Table)) {
+
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer func() { ct.widthFloor = nil }()
+
+	terminal := isTerminalWriter(ct.writer())
+
+	for first := true; ; first = false {
+		ct.clearRows()
+		refresh(ct)
+
+		// the layout's widths are at least the previous ones, so keeping them keeps the widest seen so far
+		ct.widthFloor = ct.computeLayout().widths
+
+		if terminal {
+			io.WriteString(ct.writer(), clearScreen)
+		} else if !first {
+			ct.printLine("")
+		}
+		ct.Display(true)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package ctable
+
+import (
+	"unicode"
+)
+
+/*
+Character widths.
+
+Terminals give most characters one column, but CJK characters, fullwidth forms, and most emoji take two, and combining
+marks (accents added to the character before them) take none. Widths are measured per character accordingly, so tables
+of Japanese or Chinese text, or with emoji in them, still line up:
+
+	名前         サイズ
+	============ ======
+	設定ファイル     12
+	🚀 launch         3
+
+SetWidthFunc() replaces the measuring, for terminals (or fonts) that see some characters differently - e.g. ambiguous
+width characters as two columns wide in a CJK locale:
+
+	ctable.SetWidthFunc(func(r rune) int {
+		if unicode.Is(ambiguous, r) {
+			return 2
+		}
+		return ctable.RuneWidth(r)
+	})
+
+Like SetDefaults(), it's meant for program startup, not for calling while tables are being displayed.
+*/
+
+var widthFunc = RuneWidth
+
+// SetWidthFunc sets how many columns each character takes up on screen, nil goes back to RuneWidth().
+func SetWidthFunc(fn func(r rune) int) {
+
+	if fn == nil {
+		fn = RuneWidth
+	}
+
+	widthFunc = fn
+}
+
+// RuneWidth returns the number of columns r takes up on a terminal - 2 for East Asian wide and fullwidth characters and
+// emoji, 0 for combining marks and other zero width characters, 1 for the rest.
+func RuneWidth(r rune) int {
+
+	switch {
+	case r < 0x300:
+		return 1
+	case r == 0x200b, unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	}
+
+	return 1
+}
+
+// East Asian Wide and Fullwidth characters, and the emoji blocks shown as wide
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1}, // Hangul Jamo initial consonants
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1}, // watch, hourglass
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1}, // CJK radicals, symbols and punctuation
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1}, // kana, Bopomofo, CJK compatibility etc.
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1}, // CJK unified ideographs extension A
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1}, // CJK unified ideographs
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1}, // Yi
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1}, // Hangul Jamo extended A
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1}, // Hangul syllables
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1}, // CJK compatibility ideographs
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1}, // vertical forms
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1}, // CJK compatibility forms, small form variants
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1}, // fullwidth forms
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18cff, Stride: 1}, // Tangut
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1}, // kana supplement and extensions, Nushu
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f2ff, Stride: 1}, // enclosed ideographic supplement
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1}, // pictographs, emoticons
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1}, // transport and map symbols
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1}, // supplemental symbols and pictographs
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1}, // symbols and pictographs extended A
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1}, // CJK unified ideographs extensions B onwards
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
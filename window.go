@@ -0,0 +1,52 @@
+package ctable
+
+/*
+Windowed rendering.
+
+For viewers over large tables only the lines actually on screen should be formatted. The layout (column widths etc.)
+is worked out from the full data set once and cached, so every window lines up with every other window and scrolling
+costs only the lines shown. The cache is dropped when rows are added - call ResetWindow() after changing column
+settings (justification etc.) so they're picked up.
+*/
+
+// RenderWindow formats the display lines from top up to (not including) top+height and returns them,
+// a window running past the end of the table just returns fewer lines. Header lines aren't included, see RenderWindowHeader().
+func (ct *Table) RenderWindow(top int, height int) []string {
+
+	lay := ct.cachedLayout()
+
+	if top < 0 {
+		top = 0
+	}
+	end := top + height
+	if end > lay.lines {
+		end = lay.lines
+	}
+
+	lines := []string{}
+	for i := top; i < end; i++ {
+		lines = append(lines, ct.formatLine(lay.lineAt(i), lay))
+	}
+
+	return lines
+}
+
+// RenderWindowHeader returns the header and header separator lines laid out to match RenderWindow().
+func (ct *Table) RenderWindowHeader() (string, string) {
+	return ct.formatHeader(ct.cachedLayout())
+}
+
+// ResetWindow drops the layout cached for RenderWindow() so it's worked out again on the next call.
+func (ct *Table) ResetWindow() {
+	ct.windowLayout = nil
+}
+
+func (ct *Table) cachedLayout() layout {
+
+	if ct.windowLayout == nil {
+		lay := ct.computeLayout()
+		ct.windowLayout = &lay
+	}
+
+	return *ct.windowLayout
+}
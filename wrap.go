@@ -0,0 +1,193 @@
+package ctable
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Word wrap.
+
+A column with a truncateAt normally cuts longer values short with "...". With Wrap set, values are wrapped at word
+boundaries onto as many lines as they need instead, so long descriptions can be read in full - the row is displayed
+as a multiline row, just as if the value had been added as lines:
+
+	col := ctable.NewColumn("Description", 30)
+	col.Wrap = true
+	col.WrapMarker = "↳ "
+
+	Name  Description
+	===== ==============================
+	web-1 Serves the public site behind
+	      ↳ the load balancer, restarted
+	      ↳ nightly
+	db-1  Primary database
+
+WrapMarker (e.g. "↳ " or "\ ") starts each continuation line of a wrapped value, so wrapped text can be told from lines
+of a multiline value, "" for none. Words longer than the column are broken where they reach the edge.
+
+Values are wrapped when the table is laid out, to the width the column is displayed at, so Wrap and WrapMarker can be
+changed at any time and FitToWidth wraps a Wrap column it narrows rather than cutting its values short. The values
+themselves stay as they were added - Row(), exports, and the rest see them unwrapped.
+*/
+
+// wrapValues wraps the values of the Wrap columns shown that are too wide for their column, adding wrap lines to the
+// layout after the lines of a row for the lines it runs on to
+func (ct *Table) wrapValues(lay *layout) {
+
+	cols := []int{}
+	for _, c := range lay.columns() {
+		if ct.Columns[c].Wrap {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		return
+	}
+
+	lay.wrapBase = ct.lineCount()
+
+	for pos := 0; pos < lay.rows; pos++ {
+		r := lay.rowAt(pos)
+		first, end := ct.rowLines(r)
+		if _, ok := lay.summaries[first]; ok {
+			continue
+		}
+
+		// each line of a value is wrapped on its own, the lines of the wrapped value following one another down the row
+		lines := end - first
+		wrapped := map[int][]string{}
+		for _, c := range cols {
+			width := lay.fieldRoom(first, c)
+			if width < 1 {
+				width = 1
+			}
+			value, wraps := []string{}, false
+			for l := first; l < end; l++ {
+				split := wrapText(ct.displayedCell(l, c), width, ct.Columns[c].WrapMarker)
+				value = append(value, split...)
+				wraps = wraps || len(split) > 1
+			}
+			if !wraps {
+				continue
+			}
+			// (shorter multiline values are padded out with blank lines, those go at the end)
+			for len(value) > 0 && value[len(value)-1] == "" {
+				value = value[:len(value)-1]
+			}
+			if len(value) > lines {
+				lines = len(value)
+			}
+			wrapped[c] = value
+		}
+		if len(wrapped) == 0 {
+			continue
+		}
+
+		if lay.wrapped == nil {
+			lay.wrapped = map[int]string{}
+			lay.wrapLines = map[int][]int{}
+		}
+		for k := end - first; k < lines; k++ {
+			l := lay.wrapBase + len(lay.wrapSources)
+			lay.wrapSources = append(lay.wrapSources, first)
+			lay.wrapLines[r] = append(lay.wrapLines[r], l)
+			if lay.prefixes != nil {
+				lay.prefixes = append(lay.prefixes, lay.stems[r])
+			}
+			if lay.lineStyles != nil {
+				lay.lineStyles = append(lay.lineStyles, lay.lineStyles[first])
+			}
+		}
+		rowLines := ct.appendRowLines(nil, *lay, r)
+		for c, value := range wrapped {
+			for k, l := range rowLines {
+				lay.wrapped[l*ct.ColumnCount+c] = ""
+				if k < len(value) {
+					lay.wrapped[l*ct.ColumnCount+c] = value[k]
+				}
+			}
+		}
+	}
+
+	if lay.wrapSources != nil {
+		ct.orderLines(lay)
+	}
+}
+
+// isWrapLine reports whether l (from the layout's line order) is a wrap line, a line a row runs on to for its wrapped values
+func (lay layout) isWrapLine(l int) bool {
+	return lay.wrapSources != nil && l >= lay.wrapBase
+}
+
+// sourceLine returns the stored display line l shows, the first line of the row for a wrap line
+func (lay layout) sourceLine(l int) int {
+
+	if lay.isWrapLine(l) {
+		return lay.wrapSources[l-lay.wrapBase]
+	}
+
+	return l
+}
+
+// wrapText breaks s into lines no wider than width at the spaces between words, every line after the first starting with marker
+func wrapText(s string, width int, marker string) []string {
+
+	if textWidth(s) <= width {
+		return []string{s}
+	}
+
+	lines := []string{}
+	line := ""
+	room := width
+
+	newLine := func() {
+		lines = append(lines, line)
+		line = marker
+		room = width - textWidth(marker)
+		if room < 1 {
+			room = 1
+		}
+	}
+
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line != "" && line != marker && textWidth(line)+1+textWidth(word) <= width:
+			line += " " + word
+			continue
+		case line != "" && line != marker:
+			newLine()
+		}
+
+		// words too long for a line of their own are broken at the edge
+		for textWidth(word) > room {
+			head, rest := splitAtWidth(word, room)
+			line += head
+			word = rest
+			newLine()
+		}
+		line += word
+	}
+
+	return append(lines, line)
+}
+
+// splitAtWidth splits s after n columns (after the first character, however wide)
+func splitAtWidth(s string, n int) (string, string) {
+
+	width := 0
+	for i := 0; i < len(s); {
+		if seqLen := ansiSequenceLength(s[i:]); seqLen > 0 {
+			i += seqLen
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if width+widthFunc(r) > n && width > 0 {
+			return s[:i], s[i:]
+		}
+		i += size
+		width += widthFunc(r)
+	}
+
+	return s, ""
+}
@@ -0,0 +1,81 @@
+package ctable
+
+import (
+	"io"
+	"strings"
+)
+
+/*
+Output to any writer.
+
+Fprint() displays the table to an io.Writer - a buffer in a test, an HTTP response, a log file - exactly as Display()
+prints it to stdout (Display() is Fprint() to the table's usual output), and returns the first error writing to it:
+
+	var buf bytes.Buffer
+	err := ct.Fprint(&buf, true)
+
+Colors and styles are kept when w is a terminal (see color.go), wrap w with ColorWriter() or PlainWriter() to say
+otherwise. Settings that depend on output going to a terminal (PipedFormat, Paginate) look at w too.
+
+Render() returns the table as a string instead, with headers, as it would be written to a file - to put in an error
+message, a log entry, or a TUI widget. String() is the same without the error, so a *Table can be printed with %v:
+
+	s, err := ct.Render()
+	return fmt.Errorf("unexpected nodes:\n%v", &ct)
+*/
+
+// Fprint displays the table to w.
+func (ct *Table) Fprint(w io.Writer, showHeaders bool) error {
+
+	tw, ok := w.(*targetWriter)
+	if !ok {
+		tw = &targetWriter{w: w}
+	}
+	tw.err = nil
+
+	out := ct.output
+	ct.output = tw
+	defer func() { ct.output = out }()
+
+	ct.display(showHeaders)
+
+	return tw.err
+}
+
+// Render returns the table (with headers) as a string.
+func (ct *Table) Render() (string, error) {
+
+	var sb strings.Builder
+	err := ct.Fprint(&sb, true)
+
+	return sb.String(), err
+}
+
+// String returns the table (with headers) as a string.
+func (ct *Table) String() string {
+	s, _ := ct.Render()
+	return s
+}
+
+const (
+	colorAuto = iota
+	colorAlways
+	colorNever
+)
+
+// targetWriter is a writer the table is displayed to, with its color setting (see tee.go) and the first error writing to it
+type targetWriter struct {
+	w     io.Writer
+	color int
+	err   error
+}
+
+func (tw *targetWriter) Write(p []byte) (int, error) {
+
+	n, err := tw.w.Write(p)
+	if err != nil && tw.err == nil {
+		tw.err = err
+	}
+
+	return n, err
+}